@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"sync"
+
+	"github.com/wailsapp/wails/v2/pkg/runtime"
 )
 
 // App struct
@@ -11,7 +13,16 @@ type App struct {
 	ctx             context.Context
 	config          *Config
 	championManager *ChampionManager
-	lcuConnector    *LCUConnector
+	lcuConnector    Connector
+	newConnector    func(*App) Connector
+	riotClient      *RiotClientConnector
+	liveGame        *LiveGamePoller
+	history         *GameHistory
+	matchHistory    *MatchHistoryCache
+	mastery         *MasteryService
+	matchups        *MatchupTable
+	runeLibrary     *RuneLibrary
+	itemSetLibrary  *ItemSetLibrary
 	mu              sync.RWMutex
 }
 
@@ -20,6 +31,14 @@ func NewApp() *App {
 	return &App{}
 }
 
+// createConnector 创建LCU连接器，未指定工厂时使用默认实现
+func (a *App) createConnector() Connector {
+	if a.newConnector != nil {
+		return a.newConnector(a)
+	}
+	return NewLCUConnector(a)
+}
+
 // startup is called when the app starts. The context here
 // can be used to call the runtime methods
 func (a *App) startup(ctx context.Context) {
@@ -33,13 +52,8 @@ func (a *App) startup(ctx context.Context) {
 	}
 	a.config = config
 
-	// 初始化英雄管理器
-	a.championManager = NewChampionManager()
-
-	// 加载本地英雄数据
-	if err := a.championManager.LoadChampions(); err != nil {
-		fmt.Printf("[WARNING] Failed to load champions: %v\n", err)
-	}
+	// 加载英雄数据和各类配置库
+	a.loadLibraries()
 
 	// 更新英雄数据
 	go func() {
@@ -49,8 +63,10 @@ func (a *App) startup(ctx context.Context) {
 		}
 	}()
 
-	// 初始化LCU连接器
-	a.lcuConnector = NewLCUConnector(a)
+	// 初始化LCU和Riot客户端连接器
+	a.lcuConnector = a.createConnector()
+	a.riotClient = NewRiotClientConnector(a.config)
+	a.liveGame = a.newLiveGamePoller()
 
 	// 启动LCU连接器
 	go func() {
@@ -61,6 +77,57 @@ func (a *App) startup(ctx context.Context) {
 	}()
 }
 
+// loadLibraries 加载本地英雄数据、克制关系表、符文页库、出装方案模板库、对局记录和战绩缓存，并初始化英雄成就
+func (a *App) loadLibraries() {
+	// 初始化英雄管理器
+	a.championManager = NewChampionManager()
+
+	// 加载本地英雄数据
+	if err := a.championManager.LoadChampions(); err != nil {
+		fmt.Printf("[WARNING] Failed to load champions: %v\n", err)
+	}
+
+	// 加载克制关系表
+	matchups, err := LoadMatchupTable()
+	if err != nil {
+		fmt.Printf("[WARNING] Failed to load matchups: %v\n", err)
+		matchups = NewMatchupTable()
+	}
+	a.matchups = matchups
+
+	// 加载符文页库
+	runeLibrary, err := LoadRuneLibrary()
+	if err != nil {
+		fmt.Printf("[WARNING] Failed to load rune pages: %v\n", err)
+		runeLibrary = NewRuneLibrary()
+	}
+	a.runeLibrary = runeLibrary
+
+	// 加载出装方案模板库
+	itemSetLibrary, err := LoadItemSetLibrary()
+	if err != nil {
+		fmt.Printf("[WARNING] Failed to load item sets: %v\n", err)
+		itemSetLibrary = NewItemSetLibrary()
+	}
+	a.itemSetLibrary = itemSetLibrary
+
+	history, err := LoadGameHistory()
+	if err != nil {
+		fmt.Printf("[WARNING] Failed to load game history: %v\n", err)
+		history = NewGameHistory()
+	}
+	a.history = history
+
+	matchHistory, err := LoadMatchHistoryCache()
+	if err != nil {
+		fmt.Printf("[WARNING] Failed to load match history: %v\n", err)
+		matchHistory = NewMatchHistoryCache()
+	}
+	a.matchHistory = matchHistory
+
+	a.mastery = NewMasteryService()
+}
+
 // domReady is called after front-end resources have been loaded
 func (a *App) domReady(_ context.Context) {
 	// Add your action here
@@ -83,13 +150,31 @@ func (a *App) shutdown(_ context.Context) {
 	}
 }
 
+// emitEvent 向前端发送事件
+func (a *App) emitEvent(name string, data ...interface{}) {
+	if a.ctx == nil {
+		return
+	}
+	runtime.EventsEmit(a.ctx, name, data...)
+}
+
 // API方法供前端调用
 
 // GetChampions 获取英雄列表
 func (a *App) GetChampions() []Champion {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
-	return a.championManager.GetChampions()
+
+	champions := a.championManager.GetChampions()
+	if a.mastery != nil {
+		for i := range champions {
+			if mastery, ok := a.mastery.Get(champions[i].ID); ok {
+				champions[i].MasteryLevel = mastery.ChampionLevel
+				champions[i].MasteryPoints = mastery.ChampionPoints
+			}
+		}
+	}
+	return champions
 }
 
 // GetGameVersion 获取游戏版本号
@@ -111,7 +196,10 @@ func (a *App) SaveConfig(configData map[string]interface{}) error {
 	a.mu.Lock()
 	defer a.mu.Unlock()
 
-	a.config.UpdateConfig(configData)
+	if err := a.config.UpdateConfig(configData); err != nil {
+		fmt.Printf("[ERROR] Failed to update config: %v\n", err)
+		return err
+	}
 	err := a.config.SaveConfig()
 	if err != nil {
 		fmt.Printf("[ERROR] Failed to save config: %v\n", err)
@@ -143,7 +231,7 @@ func (a *App) ReconnectLCU() error {
 		a.lcuConnector.Disconnect()
 
 		// 重新初始化连接器
-		a.lcuConnector = NewLCUConnector(a)
+		a.lcuConnector = a.createConnector()
 
 		// 尝试重新连接
 		go func() {
@@ -161,60 +249,47 @@ func (a *App) ReconnectLCU() error {
 
 // StartRankedQueue 开始单双排位赛
 func (a *App) StartRankedQueue() error {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-
-	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
-		return fmt.Errorf("LCU not connected")
-	}
-
-	// 创建单双排位赛队列 (queueId: 420)
-	lobbyData := map[string]interface{}{
-		"queueId": 420,
-	}
-
-	_, err := a.lcuConnector.request("POST", "/lol-lobby/v2/lobby", lobbyData)
-	if err != nil {
-		fmt.Printf("[ERROR] Failed to create ranked lobby: %v\n", err)
-		return fmt.Errorf("failed to create ranked lobby: %w", err)
-	}
-
-	fmt.Println("[INFO] Successfully created ranked lobby")
-	return nil
+	_, err := a.CreateLobby(rankedSoloQueueID)
+	return err
 }
 
 // GoToMainMenu 回到主界面
 func (a *App) GoToMainMenu() error {
+	return a.LeaveLobby()
+}
+
+// GetCounterPickSuggestions 获取当前英雄选择的克制建议
+func (a *App) GetCounterPickSuggestions() []CounterPickSuggestion {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	// 检查LCU连接器是否已初始化且已连接
-	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
-		return fmt.Errorf("LCU not connected")
+	if a.lcuConnector == nil {
+		return nil
 	}
+	return a.lcuConnector.GetCounterPicks()
+}
 
-	// 尝试退出当前lobby
-	_, err := a.lcuConnector.request("DELETE", "/lol-lobby/v2/lobby", nil)
+// ReloadMatchups 重新加载克制关系表
+func (a *App) ReloadMatchups() (int, error) {
+	matchups, err := LoadMatchupTable()
 	if err != nil {
-		// 如果没有lobby可以退出，创建一个匹配模式lobby然后退出
-		lobbyData := map[string]interface{}{
-			"queueId": 430, // 匹配模式队列ID
-		}
-		_, createErr := a.lcuConnector.request("POST", "/lol-lobby/v2/lobby", lobbyData)
-		if createErr != nil {
-			fmt.Printf("[ERROR] Failed to create lobby: %v\n", createErr)
-			return createErr
-		}
-
-		// 创建成功后立即退出lobby
-		_, deleteErr := a.lcuConnector.request("DELETE", "/lol-lobby/v2/lobby", nil)
-		if deleteErr != nil {
-			fmt.Printf("[ERROR] Failed to leave created lobby: %v\n", deleteErr)
-			return deleteErr
-		}
+		fmt.Printf("[ERROR] Failed to reload matchups: %v\n", err)
+		return 0, err
 	}
 
-	return nil
+	a.mu.Lock()
+	a.matchups = matchups
+	a.mu.Unlock()
+
+	fmt.Printf("[INFO] Loaded %d matchups\n", matchups.Len())
+	return matchups.Len(), nil
+}
+
+// getMatchups 获取当前的克制关系表，重新加载时会整体替换
+func (a *App) getMatchups() *MatchupTable {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.matchups
 }
 
 // PlayerProfile 玩家基本信息结构
@@ -246,12 +321,13 @@ func (a *App) GetPlayerProfile() (*PlayerProfile, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
-		return nil, fmt.Errorf("LCU not connected")
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
 	}
 
 	// 获取当前召唤师信息
-	response, err := a.lcuConnector.request("GET", "/lol-summoner/v1/current-summoner", nil)
+	response, err := lcu.Request("GET", "/lol-summoner/v1/current-summoner", nil)
 	if err != nil {
 		fmt.Printf("[ERROR] Failed to get current summoner: %v\n", err)
 		return nil, fmt.Errorf("failed to get current summoner: %w", err)
@@ -287,12 +363,13 @@ func (a *App) GetRankedStats() ([]RankedStats, error) {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
-		return nil, fmt.Errorf("LCU not connected")
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
 	}
 
 	// 获取排位统计信息
-	response, err := a.lcuConnector.request("GET", "/lol-ranked/v1/current-ranked-stats", nil)
+	response, err := lcu.Request("GET", "/lol-ranked/v1/current-ranked-stats", nil)
 	if err != nil {
 		fmt.Printf("[ERROR] Failed to get ranked stats: %v\n", err)
 		return nil, fmt.Errorf("failed to get ranked stats: %w", err)
@@ -358,19 +435,20 @@ func (a *App) UpdateStatusMessage(message string) error {
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
-		return fmt.Errorf("LCU not connected")
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
 	}
 
 	// 先获取当前的 me 数据
-	me, err := a.lcuConnector.request("GET", "/lol-chat/v1/me", nil)
+	me, err := lcu.Request("GET", "/lol-chat/v1/me", nil)
 	if err != nil {
 		return fmt.Errorf("failed to get me: %w", err)
 	}
 
 	me["statusMessage"] = message
 
-	_, err = a.lcuConnector.request("PUT", "/lol-chat/v1/me", me)
+	_, err = lcu.Request("PUT", "/lol-chat/v1/me", me)
 	if err != nil {
 		return fmt.Errorf("failed to update status message: %w", err)
 	}
@@ -383,11 +461,12 @@ func (a *App) SetRankDisguise(tier string, division string, queue string) error
 	a.mu.RLock()
 	defer a.mu.RUnlock()
 
-	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
-		return fmt.Errorf("LCU not connected")
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
 	}
 
-	me, err := a.lcuConnector.request("GET", "/lol-chat/v1/me", nil)
+	me, err := lcu.Request("GET", "/lol-chat/v1/me", nil)
 	if err != nil {
 		return fmt.Errorf("failed to get me: %w", err)
 	}
@@ -401,7 +480,7 @@ func (a *App) SetRankDisguise(tier string, division string, queue string) error
 		return fmt.Errorf("lol data not found")
 	}
 
-	_, err = a.lcuConnector.request("PUT", "/lol-chat/v1/me", me)
+	_, err = lcu.Request("PUT", "/lol-chat/v1/me", me)
 	if err != nil {
 		return fmt.Errorf("failed to set rank disguise: %w", err)
 	}
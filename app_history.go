@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// recordGameSummary 将结算汇总写入对局记录
+func (a *App) recordGameSummary(summary *GameSummary) {
+	if a.history == nil {
+		return
+	}
+
+	a.fillChampionNames(summary)
+	a.history.Update(summary.GameID, func(record *GameRecord) {
+		if record.StartedAt.IsZero() {
+			record.StartedAt = summary.EndedAt.Add(-time.Duration(summary.GameLength) * time.Second)
+		}
+		record.EndedAt = summary.EndedAt
+		record.Summary = summary
+	})
+	if err := a.history.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save game history: %v\n", err)
+	}
+
+	a.emitEvent("game-summary", summary)
+}
+
+// fillChampionNames 结算数据缺少英雄名称时从本地英雄数据补全
+func (a *App) fillChampionNames(summary *GameSummary) {
+	if a.championManager == nil {
+		return
+	}
+	fill := func(player *PlayerSummary) {
+		if player.ChampionName != "" {
+			return
+		}
+		if champ := a.championManager.GetChampionByID(player.ChampionID); champ != nil {
+			player.ChampionName = champ.Name
+		}
+	}
+
+	fill(&summary.Player)
+	for i := range summary.Teams {
+		for j := range summary.Teams[i].Players {
+			fill(&summary.Teams[i].Players[j])
+		}
+	}
+}
+
+// GetGameSummaries 获取最近n局的结算汇总，最新的在前
+func (a *App) GetGameSummaries(n int) []GameSummary {
+	if a.history == nil {
+		return []GameSummary{}
+	}
+	return a.history.RecentSummaries(n)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"fmt"
+)
+
+// GetItemSetTemplates 获取出装方案模板库中的所有模板
+func (a *App) GetItemSetTemplates() map[string]ItemSetTemplate {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.itemSetLibrary == nil {
+		return map[string]ItemSetTemplate{}
+	}
+	return a.itemSetLibrary.All()
+}
+
+// SaveItemSetTemplate 保存英雄在指定位置的出装方案模板，并记录当前游戏版本
+func (a *App) SaveItemSetTemplate(championID int, position string, template ItemSetTemplate) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	template.Patch = a.championManager.GetVersion()
+
+	a.itemSetLibrary.Set(championID, position, template)
+	if err := a.itemSetLibrary.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save item sets: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteItemSetTemplate 删除英雄在指定位置的出装方案模板
+func (a *App) DeleteItemSetTemplate(championID int, position string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	a.itemSetLibrary.Delete(championID, position)
+	if err := a.itemSetLibrary.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save item sets: %v\n", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// newLiveGamePoller 创建游戏内数据轮询器，数据变化时通知前端，对局结束时写入对局记录
+func (a *App) newLiveGamePoller() *LiveGamePoller {
+	host := lcuHost(a.config)
+	tlsConfig := riotTLSConfig(host, skipTLSVerify(a.config))
+	poller := NewLiveGamePoller(NewLiveClient(NewHTTPTransport(host, tlsConfig), liveClientPort))
+	poller.onUpdate = func(snapshot *LiveGameSnapshot) {
+		a.emitEvent("live-game-update", snapshot)
+	}
+	poller.onEvent = func(event LiveGameEvent) {
+		a.emitEvent("live-game-event", event)
+	}
+	poller.onFinish = a.recordLiveGame
+	return poller
+}
+
+// startLiveGame 开始轮询游戏内数据
+func (a *App) startLiveGame(gameID int64) {
+	if a.liveGame == nil {
+		return
+	}
+	a.liveGame.Start(gameID)
+}
+
+// stopLiveGame 停止轮询游戏内数据
+func (a *App) stopLiveGame() {
+	if a.liveGame == nil {
+		return
+	}
+	a.liveGame.Stop()
+}
+
+// recordLiveGame 将对局结束前的游戏内数据写入对局记录
+// 开始轮询时没有取到对局ID则不写入，避免和结算时写入的记录重复
+func (a *App) recordLiveGame(snapshot *LiveGameSnapshot) {
+	if a.history == nil || snapshot.GameTime == 0 {
+		return
+	}
+	if snapshot.GameID == 0 {
+		fmt.Println("[WARNING] Skipping live game record: game id is unknown")
+		return
+	}
+
+	a.history.Update(snapshot.GameID, func(record *GameRecord) {
+		record.StartedAt = snapshot.StartedAt
+		record.EndedAt = time.Now()
+		record.Live = snapshot
+	})
+	if err := a.history.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save game history: %v\n", err)
+	}
+}
+
+// GetLiveGame 获取当前对局的游戏内数据，未在对局中时返回nil
+func (a *App) GetLiveGame() *LiveGameSnapshot {
+	if a.liveGame == nil {
+		return nil
+	}
+	return a.liveGame.Snapshot()
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rankedSoloQueueID 单双排位赛队列ID
+const rankedSoloQueueID = 420
+
+// GameQueue 游戏队列信息
+type GameQueue struct {
+	ID                   int    `json:"id"`
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	ShortName            string `json:"shortName"`
+	GameMode             string `json:"gameMode"`
+	Type                 string `json:"type"`
+	Category             string `json:"category"`
+	MapID                int    `json:"mapId"`
+	QueueAvailability    string `json:"queueAvailability"`
+	IsRanked             bool   `json:"isRanked"`
+	ShowPositionSelector bool   `json:"showPositionSelector"`
+}
+
+// LobbyGameConfig 房间游戏配置
+type LobbyGameConfig struct {
+	QueueID              int    `json:"queueId"`
+	GameMode             string `json:"gameMode"`
+	IsCustom             bool   `json:"isCustom"`
+	MaxLobbySize         int    `json:"maxLobbySize"`
+	ShowPositionSelector bool   `json:"showPositionSelector"`
+}
+
+// LobbyMember 房间成员
+type LobbyMember struct {
+	SummonerID               int64  `json:"summonerId"`
+	PUUID                    string `json:"puuid"`
+	GameName                 string `json:"gameName"`
+	GameTag                  string `json:"gameTag"`
+	IsLeader                 bool   `json:"isLeader"`
+	Ready                    bool   `json:"ready"`
+	FirstPositionPreference  string `json:"firstPositionPreference"`
+	SecondPositionPreference string `json:"secondPositionPreference"`
+}
+
+// Lobby 房间信息
+type Lobby struct {
+	PartyID          string          `json:"partyId"`
+	CanStartActivity bool            `json:"canStartActivity"`
+	GameConfig       LobbyGameConfig `json:"gameConfig"`
+	LocalMember      LobbyMember     `json:"localMember"`
+	Members          []LobbyMember   `json:"members"`
+}
+
+// Friend 好友信息
+type Friend struct {
+	SummonerID   int64  `json:"summonerId"`
+	PUUID        string `json:"puuid"`
+	GameName     string `json:"gameName"`
+	GameTag      string `json:"gameTag"`
+	Name         string `json:"name"`
+	Availability string `json:"availability"`
+	Product      string `json:"product"`
+}
+
+// MatchmakingSearch 匹配状态
+type MatchmakingSearch struct {
+	SearchState        string  `json:"searchState"`
+	TimeInQueue        float64 `json:"timeInQueue"`
+	EstimatedQueueTime float64 `json:"estimatedQueueTime"`
+	IsCurrentlyInQueue bool    `json:"isCurrentlyInQueue"`
+}
+
+// validPositions 可设置的位置偏好
+var validPositions = map[string]bool{
+	"TOP":        true,
+	"JUNGLE":     true,
+	"MIDDLE":     true,
+	"BOTTOM":     true,
+	"UTILITY":    true,
+	"FILL":       true,
+	"UNSELECTED": true,
+}
+
+// connectedLCU 获取已连接的LCU连接器，调用方需持有a.mu
+func (a *App) connectedLCU() (Connector, error) {
+	if a.lcuConnector == nil || !a.lcuConnector.IsConnected() {
+		return nil, fmt.Errorf("LCU not connected")
+	}
+	return a.lcuConnector, nil
+}
+
+// GetQueues 获取当前可用的游戏队列
+func (a *App) GetQueues() ([]GameQueue, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
+	}
+
+	var queues []GameQueue
+	if err := lcu.RequestJSON("GET", "/lol-game-queues/v1/queues", nil, &queues); err != nil {
+		fmt.Printf("[ERROR] Failed to get queues: %v\n", err)
+		return nil, fmt.Errorf("failed to get queues: %w", err)
+	}
+
+	available := make([]GameQueue, 0, len(queues))
+	for _, queue := range queues {
+		if queue.QueueAvailability == "Available" {
+			available = append(available, queue)
+		}
+	}
+	return available, nil
+}
+
+// GetLobby 获取当前房间信息
+func (a *App) GetLobby() (*Lobby, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
+	}
+
+	lobby := &Lobby{}
+	if err := lcu.RequestJSON("GET", "/lol-lobby/v2/lobby", nil, lobby); err != nil {
+		return nil, fmt.Errorf("failed to get lobby: %w", err)
+	}
+	return lobby, nil
+}
+
+// CreateLobby 创建指定队列的房间
+func (a *App) CreateLobby(queueID int) (*Lobby, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
+	}
+
+	lobbyData := map[string]interface{}{
+		"queueId": queueID,
+	}
+
+	lobby := &Lobby{}
+	if err := lcu.RequestJSON("POST", "/lol-lobby/v2/lobby", lobbyData, lobby); err != nil {
+		fmt.Printf("[ERROR] Failed to create lobby for queue %d: %v\n", queueID, err)
+		return nil, fmt.Errorf("failed to create lobby: %w", err)
+	}
+
+	fmt.Printf("[INFO] Successfully created lobby for queue %d\n", queueID)
+	return lobby, nil
+}
+
+// SetPositionPreferences 设置主次位置偏好
+func (a *App) SetPositionPreferences(primary string, secondary string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
+	}
+
+	primary = strings.ToUpper(primary)
+	secondary = strings.ToUpper(secondary)
+	if !validPositions[primary] || !validPositions[secondary] {
+		return fmt.Errorf("invalid position preferences: %s, %s", primary, secondary)
+	}
+
+	preferences := map[string]interface{}{
+		"firstPreference":  primary,
+		"secondPreference": secondary,
+	}
+
+	if _, err := lcu.Request("PUT", "/lol-lobby/v2/lobby/members/localMember/position-preferences", preferences); err != nil {
+		fmt.Printf("[ERROR] Failed to set position preferences: %v\n", err)
+		return fmt.Errorf("failed to set position preferences: %w", err)
+	}
+
+	return nil
+}
+
+// GetFriends 获取好友列表
+func (a *App) GetFriends() ([]Friend, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
+	}
+
+	var friends []Friend
+	if err := lcu.RequestJSON("GET", "/lol-chat/v1/friends", nil, &friends); err != nil {
+		fmt.Printf("[ERROR] Failed to get friends: %v\n", err)
+		return nil, fmt.Errorf("failed to get friends: %w", err)
+	}
+	return friends, nil
+}
+
+// InviteFriends 邀请好友加入房间
+func (a *App) InviteFriends(summonerIDs []int64) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
+	}
+
+	if len(summonerIDs) == 0 {
+		return nil
+	}
+
+	invitations := make([]map[string]interface{}, 0, len(summonerIDs))
+	for _, summonerID := range summonerIDs {
+		invitations = append(invitations, map[string]interface{}{
+			"toSummonerId": summonerID,
+		})
+	}
+
+	if _, err := lcu.Request("POST", "/lol-lobby/v2/lobby/invitations", invitations); err != nil {
+		fmt.Printf("[ERROR] Failed to invite friends: %v\n", err)
+		return fmt.Errorf("failed to invite friends: %w", err)
+	}
+
+	fmt.Printf("[INFO] Invited %d friends to lobby\n", len(summonerIDs))
+	return nil
+}
+
+// StartMatchmaking 开始匹配
+func (a *App) StartMatchmaking() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
+	}
+
+	if _, err := lcu.Request("POST", "/lol-lobby/v2/lobby/matchmaking/search", nil); err != nil {
+		fmt.Printf("[ERROR] Failed to start matchmaking: %v\n", err)
+		return fmt.Errorf("failed to start matchmaking: %w", err)
+	}
+	return nil
+}
+
+// CancelMatchmaking 取消匹配
+func (a *App) CancelMatchmaking() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
+	}
+
+	if _, err := lcu.Request("DELETE", "/lol-lobby/v2/lobby/matchmaking/search", nil); err != nil {
+		fmt.Printf("[ERROR] Failed to cancel matchmaking: %v\n", err)
+		return fmt.Errorf("failed to cancel matchmaking: %w", err)
+	}
+	return nil
+}
+
+// GetMatchmakingSearch 获取当前匹配状态
+func (a *App) GetMatchmakingSearch() (*MatchmakingSearch, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
+	}
+
+	search := &MatchmakingSearch{}
+	if err := lcu.RequestJSON("GET", "/lol-matchmaking/v1/search", nil, search); err != nil {
+		return nil, fmt.Errorf("failed to get matchmaking search: %w", err)
+	}
+	return search, nil
+}
+
+// LeaveLobby 离开当前房间，在结算界面时返回主界面
+func (a *App) LeaveLobby() error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return err
+	}
+
+	switch lcu.GetStatus().ClientStatus {
+	case "Matchmaking":
+		// 先退出匹配再离开房间
+		if _, err := lcu.Request("DELETE", "/lol-lobby/v2/lobby/matchmaking/search", nil); err != nil {
+			fmt.Printf("[WARNING] Failed to cancel matchmaking before leaving: %v\n", err)
+		}
+	case "PreEndOfGame", "EndOfGame", "WaitingForStats":
+		// 结算界面没有房间，拒绝再来一局即可回到主界面
+		if _, err := lcu.Request("POST", "/lol-lobby/v2/play-again-decline", nil); err != nil {
+			fmt.Printf("[ERROR] Failed to leave post game: %v\n", err)
+			return fmt.Errorf("failed to leave post game: %w", err)
+		}
+		return nil
+	case "None":
+		// 已经在主界面
+		return nil
+	}
+
+	if _, err := lcu.Request("DELETE", "/lol-lobby/v2/lobby", nil); err != nil {
+		fmt.Printf("[ERROR] Failed to leave lobby: %v\n", err)
+		return fmt.Errorf("failed to leave lobby: %w", err)
+	}
+
+	fmt.Println("[INFO] Left lobby")
+	return nil
+}
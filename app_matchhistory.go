@@ -0,0 +1,125 @@
+package main
+
+import "fmt"
+
+const (
+	// suggestionRecentGames 按胜率推荐英雄时统计的最近对局数
+	suggestionRecentGames = 50
+	// suggestionMinGames 参与胜率推荐的英雄至少需要的场次
+	suggestionMinGames = 2
+)
+
+// currentPUUID 获取当前玩家的puuid
+func currentPUUID(lcu jsonRequester) (string, error) {
+	var summoner struct {
+		PUUID string `json:"puuid"`
+	}
+	if err := lcu.RequestJSON("GET", "/lol-summoner/v1/current-summoner", nil, &summoner); err != nil {
+		return "", fmt.Errorf("failed to get current summoner: %w", err)
+	}
+	if summoner.PUUID == "" {
+		return "", fmt.Errorf("current summoner has no puuid")
+	}
+	return summoner.PUUID, nil
+}
+
+// GetMatchHistory 获取当前玩家从begIndex开始的一页战绩并写入本地缓存
+// LCU未连接时返回缓存中的数据
+func (a *App) GetMatchHistory(begIndex int) ([]MatchRecord, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if begIndex < 0 {
+		begIndex = 0
+	}
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		cached := a.matchHistory.Get(a.matchHistory.GetLastPUUID())
+		if begIndex >= len(cached) {
+			return []MatchRecord{}, nil
+		}
+		end := begIndex + matchHistoryPageSize
+		if end > len(cached) {
+			end = len(cached)
+		}
+		return cached[begIndex:end], nil
+	}
+
+	puuid, err := currentPUUID(lcu)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := fetchMatchHistory(lcu, puuid, begIndex)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get match history: %v\n", err)
+		return nil, fmt.Errorf("failed to get match history: %w", err)
+	}
+
+	a.matchHistory.SetLastPUUID(puuid)
+	a.matchHistory.Merge(puuid, records)
+	if err := a.matchHistory.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save match history: %v\n", err)
+	}
+
+	return records, nil
+}
+
+// SyncMatchHistory 连续获取多页战绩写入本地缓存，返回缓存中当前玩家的对局数
+func (a *App) SyncMatchHistory(pages int) (int, error) {
+	if pages <= 0 {
+		pages = 1
+	}
+
+	for page := 0; page < pages; page++ {
+		records, err := a.GetMatchHistory(page * matchHistoryPageSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(records) < matchHistoryPageSize {
+			break
+		}
+	}
+
+	return len(a.matchHistory.Get(a.matchHistory.GetLastPUUID())), nil
+}
+
+// GetChampionPerformance 根据缓存的战绩统计当前玩家每个英雄的胜率、KDA和近期状态
+// position为空时统计所有位置
+func (a *App) GetChampionPerformance(position string) []ChampionPerformance {
+	records := a.matchHistory.Get(a.matchHistory.GetLastPUUID())
+	return ChampionPerformances(records, position)
+}
+
+// GetPositionPerformance 根据缓存的战绩统计当前玩家每个位置的胜率、KDA和近期状态
+func (a *App) GetPositionPerformance() []PositionPerformance {
+	records := a.matchHistory.Get(a.matchHistory.GetLastPUUID())
+	return PositionPerformances(records)
+}
+
+// recentMatches 获取当前玩家缓存中最近的对局，用于胜率推荐和排序
+func (a *App) recentMatches() []MatchRecord {
+	if a.matchHistory == nil {
+		return nil
+	}
+	records := a.matchHistory.Get(a.matchHistory.GetLastPUUID())
+	if len(records) > suggestionRecentGames {
+		records = records[:suggestionRecentGames]
+	}
+	return records
+}
+
+// sortByWinRate 按当前玩家在指定位置的近期胜率排列候选英雄
+func (a *App) sortByWinRate(position string, championIDs []int) []int {
+	return SortByWinRate(a.recentMatches(), position, suggestionMinGames, championIDs)
+}
+
+// GetWinRatePickSuggestions 推荐当前玩家在指定位置近期胜率最高的n个英雄
+func (a *App) GetWinRatePickSuggestions(position string, n int) []ChampionPerformance {
+	suggestions := TopChampionsByWinRate(a.recentMatches(), position, suggestionMinGames, n)
+	if suggestions == nil {
+		return []ChampionPerformance{}
+	}
+	return suggestions
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// leagueLaunchTimeout 启动英雄联盟后等待LCU可连接的最长时间
+	leagueLaunchTimeout = 2 * time.Minute
+	// leagueLaunchPollInterval 等待LCU时的重试间隔
+	leagueLaunchPollInterval = 5 * time.Second
+)
+
+// GetRiotClientStatus 获取Riot客户端状态
+// 前端据此区分"Riot客户端已打开但英雄联盟未启动"等情况
+func (a *App) GetRiotClientStatus() *RiotClientStatus {
+	// 查询状态需要多次HTTP请求，不在持有a.mu时进行
+	a.mu.RLock()
+	riotClient := a.riotClient
+	a.mu.RUnlock()
+
+	if riotClient == nil {
+		return &RiotClientStatus{}
+	}
+	return riotClient.GetStatus()
+}
+
+// LaunchLeagueClient 通过Riot客户端启动英雄联盟，并在客户端就绪后自动连接LCU
+func (a *App) LaunchLeagueClient() error {
+	a.mu.RLock()
+	riotClient := a.riotClient
+	lcuConnector := a.lcuConnector
+	a.mu.RUnlock()
+
+	if riotClient == nil {
+		return fmt.Errorf("Riot Client connector not initialized")
+	}
+	if err := riotClient.Connect(); err != nil {
+		return err
+	}
+	if !riotClient.IsLoggedIn() {
+		return fmt.Errorf("not logged in to Riot Client")
+	}
+
+	if err := riotClient.LaunchLeague(); err != nil {
+		fmt.Printf("[ERROR] Failed to launch League client: %v\n", err)
+		return fmt.Errorf("failed to launch League client: %w", err)
+	}
+	fmt.Println("[INFO] League client launch requested")
+
+	if lcuConnector != nil && !lcuConnector.IsConnected() {
+		go a.waitForLCU(lcuConnector)
+	}
+	return nil
+}
+
+// waitForLCU 在英雄联盟客户端启动期间反复尝试连接LCU
+func (a *App) waitForLCU(lcu Connector) {
+	deadline := time.Now().Add(leagueLaunchTimeout)
+	for time.Now().Before(deadline) {
+		time.Sleep(leagueLaunchPollInterval)
+		if lcu.IsConnected() {
+			return
+		}
+		if err := lcu.Connect(); err == nil {
+			fmt.Println("[INFO] Connected to LCU after launch")
+			return
+		}
+	}
+	fmt.Println("[WARNING] Timed out waiting for League client to start")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+)
+
+// GetRunePages 获取符文页库中的所有符文页
+func (a *App) GetRunePages() map[string]RunePage {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if a.runeLibrary == nil {
+		return map[string]RunePage{}
+	}
+	return a.runeLibrary.All()
+}
+
+// SaveRunePage 保存英雄在指定位置的符文页，位置为空表示通用符文页
+func (a *App) SaveRunePage(championID int, position string, page RunePage) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	if err := page.Validate(); err != nil {
+		return err
+	}
+
+	a.runeLibrary.Set(championID, position, page)
+	if err := a.runeLibrary.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save rune pages: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// DeleteRunePage 删除英雄在指定位置的符文页
+func (a *App) DeleteRunePage(championID int, position string) error {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	a.runeLibrary.Delete(championID, position)
+	if err := a.runeLibrary.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save rune pages: %v\n", err)
+		return err
+	}
+	return nil
+}
+
+// SaveCurrentRunePage 将客户端当前的符文页保存到符文页库
+func (a *App) SaveCurrentRunePage(championID int, position string) (*RunePage, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	lcu, err := a.connectedLCU()
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := fetchCurrentRunePage(lcu)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current rune page: %w", err)
+	}
+	if err := page.Validate(); err != nil {
+		return nil, err
+	}
+
+	a.runeLibrary.Set(championID, position, *page)
+	if err := a.runeLibrary.Save(); err != nil {
+		fmt.Printf("[ERROR] Failed to save rune pages: %v\n", err)
+		return nil, err
+	}
+	return page, nil
+}
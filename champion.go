@@ -11,8 +11,13 @@ import (
 
 // Champion 英雄信息结构体
 type Champion struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID   int      `json:"id"`
+	Name string   `json:"name"`
+	Tags []string `json:"tags"` // 英雄类型（Fighter、Tank、Mage等）以及伤害类型AP/AD
+
+	// 当前召唤师的英雄成就，只在返回给前端时填充
+	MasteryLevel  int `json:"mastery_level,omitempty"`
+	MasteryPoints int `json:"mastery_points,omitempty"`
 }
 
 // ChampionData 英雄数据结构体
@@ -23,8 +28,25 @@ type ChampionData struct {
 
 // DDragonChampion Data Dragon API返回的英雄结构
 type DDragonChampion struct {
-	Key  string `json:"key"`
-	Name string `json:"name"`
+	Key  string      `json:"key"`
+	Name string      `json:"name"`
+	Tags []string    `json:"tags"`
+	Info DDragonInfo `json:"info"`
+}
+
+// DDragonInfo Data Dragon英雄评分
+type DDragonInfo struct {
+	Attack  int `json:"attack"`
+	Defense int `json:"defense"`
+	Magic   int `json:"magic"`
+}
+
+// damageTag 根据英雄评分判断主要伤害类型
+func (info DDragonInfo) damageTag() string {
+	if info.Magic > info.Attack {
+		return "AP"
+	}
+	return "AD"
 }
 
 // DDragonResponse Data Dragon API响应结构
@@ -161,9 +183,13 @@ func (cm *ChampionManager) FetchChampionsData(version string) error {
 			continue // 跳过无法解析的英雄
 		}
 		
+		tags := append([]string{}, champ.Tags...)
+		tags = append(tags, champ.Info.damageTag())
+		
 		champions[champ.Key] = Champion{
 			ID:   id,
 			Name: champ.Name,
+			Tags: tags,
 		}
 	}
 	
@@ -183,7 +209,8 @@ func (cm *ChampionManager) UpdateChampionsIfNeeded() error {
 		return nil // 不返回错误，使用现有数据
 	}
 	
-	if cm.data.Version != latestVersion {
+	// 旧版本的英雄数据没有标签，需要重新获取
+	if cm.data.Version != latestVersion || !cm.hasTags() {
 		fmt.Printf("[INFO] Updating champions data from %s to %s\n", cm.data.Version, latestVersion)
 		
 		if err := cm.FetchChampionsData(latestVersion); err != nil {
@@ -224,4 +251,22 @@ func (cm *ChampionManager) GetChampionByID(id int) *Champion {
 		}
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// GetChampionTags 获取英雄标签
+func (cm *ChampionManager) GetChampionTags(id int) []string {
+	if champ := cm.GetChampionByID(id); champ != nil {
+		return champ.Tags
+	}
+	return nil
+}
+
+// hasTags 检查英雄数据是否包含标签
+func (cm *ChampionManager) hasTags() bool {
+	for _, champ := range cm.data.Data {
+		if len(champ.Tags) == 0 {
+			return false
+		}
+	}
+	return true
+}
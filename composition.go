@@ -0,0 +1,161 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// 阵容规则的动作
+const (
+	RuleActionPrefer = "prefer" // 优先选择带有该标签的英雄
+	RuleActionAvoid  = "avoid"  // 尽量不选带有该标签的英雄
+	RuleActionForbid = "forbid" // 禁止选择带有该标签的英雄
+)
+
+// CompositionRule 阵容规则
+// 例如 "已有两个AP时优先AD": {when_tag: "AP", min_count: 2, action: "prefer", tag: "AD"}
+// "不选第二个坦克": {when_tag: "Tank", min_count: 1, action: "forbid", tag: "Tank"}
+type CompositionRule struct {
+	Name     string `json:"name"`
+	WhenTag  string `json:"when_tag"`  // 队伍已锁定英雄中需要统计的标签，为空表示总是生效
+	MinCount int    `json:"min_count"` // 带有WhenTag的英雄数量达到该值时规则生效
+	Action   string `json:"action"`
+	Tag      string `json:"tag"` // 规则作用于带有该标签的候选英雄
+}
+
+// CompositionResult 单个候选英雄的规则评估结果
+type CompositionResult struct {
+	ChampionID int      `json:"championId"`
+	Score      int      `json:"score"`
+	Forbidden  bool     `json:"forbidden"`
+	Reasons    []string `json:"reasons"`
+}
+
+// Validate 检查规则是否有效
+func (r CompositionRule) Validate() error {
+	switch r.Action {
+	case RuleActionPrefer, RuleActionAvoid, RuleActionForbid:
+	default:
+		return fmt.Errorf("rule %q: unknown action %q", r.Name, r.Action)
+	}
+	if r.Tag == "" {
+		return fmt.Errorf("rule %q: tag is required", r.Name)
+	}
+	return nil
+}
+
+// label 规则在日志中的名称
+func (r CompositionRule) label() string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("%s %s when %d %s", r.Action, r.Tag, r.MinCount, r.WhenTag)
+}
+
+// EvaluateComposition 按阵容规则评估候选英雄
+// teamChampions为队友已锁定的英雄，tagsOf返回英雄标签。
+// 结果按分数降序排列，分数相同时保持候选顺序，被禁止的英雄排在最后
+func EvaluateComposition(rules []CompositionRule, candidates []int, teamChampions []int, tagsOf func(int) []string) []CompositionResult {
+	// 统计队伍中各标签的数量
+	tagCounts := make(map[string]int)
+	for _, championID := range teamChampions {
+		for _, tag := range tagsOf(championID) {
+			tagCounts[strings.ToUpper(tag)]++
+		}
+	}
+
+	results := make([]CompositionResult, 0, len(candidates))
+	for _, championID := range candidates {
+		result := CompositionResult{ChampionID: championID}
+		tags := make(map[string]bool)
+		for _, tag := range tagsOf(championID) {
+			tags[strings.ToUpper(tag)] = true
+		}
+
+		for _, rule := range rules {
+			if rule.Validate() != nil {
+				continue
+			}
+			if rule.WhenTag != "" && tagCounts[strings.ToUpper(rule.WhenTag)] < rule.MinCount {
+				continue
+			}
+			if !tags[strings.ToUpper(rule.Tag)] {
+				continue
+			}
+
+			switch rule.Action {
+			case RuleActionPrefer:
+				result.Score++
+			case RuleActionAvoid:
+				result.Score--
+			case RuleActionForbid:
+				result.Forbidden = true
+			}
+			result.Reasons = append(result.Reasons, rule.label())
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Forbidden != results[j].Forbidden {
+			return !results[i].Forbidden
+		}
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// ApplyComposition 按阵容规则重新排列候选英雄并去掉被禁止的英雄
+func ApplyComposition(rules []CompositionRule, candidates []int, teamChampions []int, tagsOf func(int) []string) ([]int, []CompositionResult) {
+	if len(rules) == 0 {
+		return candidates, nil
+	}
+
+	results := EvaluateComposition(rules, candidates, teamChampions, tagsOf)
+	ordered := make([]int, 0, len(results))
+	for _, result := range results {
+		if !result.Forbidden {
+			ordered = append(ordered, result.ChampionID)
+		}
+	}
+	return ordered, results
+}
+
+// getAllyLockedChampions 从英雄选择会话中获取队友已锁定的英雄（不包括自己）
+func getAllyLockedChampions(data map[string]interface{}, localCellID int) []int {
+	allyCells := make(map[int]bool)
+	if myTeam, ok := data["myTeam"].([]interface{}); ok {
+		for _, player := range myTeam {
+			if playerMap, ok := player.(map[string]interface{}); ok {
+				if cellID, ok := playerMap["cellId"].(float64); ok && int(cellID) != localCellID {
+					allyCells[int(cellID)] = true
+				}
+			}
+		}
+	}
+
+	var locked []int
+	actions, _ := data["actions"].([]interface{})
+	for _, actionGroup := range actions {
+		group, ok := actionGroup.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, action := range group {
+			actionMap, ok := action.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			actorCellID, _ := actionMap["actorCellId"].(float64)
+			aType, _ := actionMap["type"].(string)
+			completed, _ := actionMap["completed"].(bool)
+			championID, _ := actionMap["championId"].(float64)
+			if aType == "pick" && completed && championID > 0 && allyCells[int(actorCellID)] {
+				locked = append(locked, int(championID))
+			}
+		}
+	}
+
+	return locked
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// testChampionTags 测试用的英雄标签
+var testChampionTags = map[int][]string{
+	1:   {"Mage", "AP"},
+	22:  {"Marksman", "AD"},
+	32:  {"Tank", "AP"},
+	54:  {"Tank"},
+	99:  {"Mage", "AP"},
+	103: {"Mage", "AP"},
+	157: {"Fighter", "AD"},
+	238: {"Assassin", "AD"},
+}
+
+func testTagsOf(championID int) []string {
+	return testChampionTags[championID]
+}
+
+func TestApplyComposition(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      []CompositionRule
+		candidates []int
+		team       []int
+		want       []int
+	}{
+		{
+			name:       "no rules keeps order",
+			candidates: []int{103, 157, 238},
+			team:       []int{32},
+			want:       []int{103, 157, 238},
+		},
+		{
+			name:       "prefer below min count has no effect",
+			rules:      []CompositionRule{{WhenTag: "AP", MinCount: 2, Action: RuleActionPrefer, Tag: "AD"}},
+			candidates: []int{103, 157},
+			team:       []int{32},
+			want:       []int{103, 157},
+		},
+		{
+			name:       "prefer at min count moves tagged first",
+			rules:      []CompositionRule{{WhenTag: "ap", MinCount: 2, Action: RuleActionPrefer, Tag: "ad"}},
+			candidates: []int{103, 157, 1, 238},
+			team:       []int{32, 99},
+			want:       []int{157, 238, 103, 1},
+		},
+		{
+			name:       "avoid moves tagged last",
+			rules:      []CompositionRule{{Action: RuleActionAvoid, Tag: "Mage"}},
+			candidates: []int{103, 157, 238},
+			want:       []int{157, 238, 103},
+		},
+		{
+			name:       "forbid removes tagged",
+			rules:      []CompositionRule{{WhenTag: "Tank", MinCount: 1, Action: RuleActionForbid, Tag: "Tank"}},
+			candidates: []int{32, 157, 54},
+			team:       []int{54},
+			want:       []int{157},
+		},
+		{
+			name: "forbid wins over prefer",
+			rules: []CompositionRule{
+				{Action: RuleActionPrefer, Tag: "AD"},
+				{Action: RuleActionForbid, Tag: "Assassin"},
+			},
+			candidates: []int{103, 238, 157},
+			want:       []int{157, 103},
+		},
+		{
+			name:       "invalid rules are ignored",
+			rules:      []CompositionRule{{Action: "boost", Tag: "AD"}, {Action: RuleActionForbid}},
+			candidates: []int{103, 157},
+			want:       []int{103, 157},
+		},
+		{
+			name:       "all forbidden",
+			rules:      []CompositionRule{{Action: RuleActionForbid, Tag: "AP"}},
+			candidates: []int{1, 103},
+			want:       []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := ApplyComposition(tt.rules, tt.candidates, tt.team, testTagsOf)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ApplyComposition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateCompositionReasons(t *testing.T) {
+	rules := []CompositionRule{
+		{Name: "second AP", WhenTag: "AP", MinCount: 1, Action: RuleActionAvoid, Tag: "AP"},
+		{Name: "mages", Action: RuleActionPrefer, Tag: "Mage"},
+	}
+
+	results := EvaluateComposition(rules, []int{103, 157}, []int{32}, testTagsOf)
+	want := []CompositionResult{
+		{ChampionID: 103, Score: 0, Reasons: []string{"second AP", "mages"}},
+		{ChampionID: 157, Score: 0},
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("EvaluateComposition() = %+v, want %+v", results, want)
+	}
+}
+
+func TestApplyCompositionSessionSnapshot(t *testing.T) {
+	raw, err := os.ReadFile("testdata/composition_session.json")
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var session map[string]interface{}
+	if err := json.Unmarshal(raw, &session); err != nil {
+		t.Fatalf("failed to parse fixture: %v", err)
+	}
+
+	// 只统计队友已锁定的英雄，不包括敌方和未锁定的队友
+	team := getAllyLockedChampions(session, 2)
+	if want := []int{54, 32}; !reflect.DeepEqual(team, want) {
+		t.Fatalf("getAllyLockedChampions() = %v, want %v", team, want)
+	}
+
+	rules := []CompositionRule{
+		{Name: "one tank is enough", WhenTag: "Tank", MinCount: 2, Action: RuleActionForbid, Tag: "Tank"},
+		{Name: "need AD", WhenTag: "AP", MinCount: 1, Action: RuleActionPrefer, Tag: "AD"},
+	}
+	got, _ := ApplyComposition(rules, []int{32, 103, 238, 157}, team, testTagsOf)
+	if want := []int{238, 157, 103}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyComposition() = %v, want %v", got, want)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 )
 
 // Config 配置结构体
@@ -17,8 +18,62 @@ type Config struct {
 	AutoBanChampionID    *int                   `json:"auto_ban_champion_id"`
 	AutoPickChampionID   *int                   `json:"auto_pick_champion_id"`
 	PositionChampions    map[string]*int        `json:"position_champions"`
+	PositionBanChampions map[string]*int        `json:"position_ban_champions"`
+	BanBackupChampionIDs []int                  `json:"ban_backup_champion_ids"` // 首选被队友预选时依次替补
+
+	// 英雄池与克制选择
+	PositionChampionPools map[string][]int      `json:"position_champion_pools"` // 每个位置的备选英雄，DEFAULT用于未分配位置
+	CounterPickMode       string                `json:"counter_pick_mode"`       // off, preselect, lock
+	CompositionRules      []CompositionRule     `json:"composition_rules"`       // 阵容规则，按顺序评估
+	PickOrderByMastery    bool                  `json:"pick_order_by_mastery"`   // 按英雄成就点数排列候选英雄，没有配置英雄时选择成就最高的可用英雄
+	PickOrderByWinRate    bool                  `json:"pick_order_by_win_rate"`  // 按近期战绩中该位置的胜率排列候选英雄，优先于成就排序
+
+	// 锁定英雄后的配置
+	RuneImportEnabled     bool                  `json:"rune_import_enabled"`
+	SpellsEnabled         bool                  `json:"spells_enabled"`
+	ChampionSpells        map[string]SpellPair  `json:"champion_spells"` // 键为 "英雄ID:位置"、"英雄ID" 或 "位置"
+	FlashOnF              bool                  `json:"flash_on_f"`      // 闪现放在F键，否则放在D键
+	ItemSetsEnabled       bool                  `json:"item_sets_enabled"`
+	PreferredSkins        map[string]int        `json:"preferred_skins"` // 英雄ID到皮肤或炫彩ID，-1表示随机已拥有的皮肤
+
+	// 极地大乱斗
+	AramEnabled           bool                  `json:"aram_enabled"`
+	AramTierList          []int                 `json:"aram_tier_list"`          // 按优先级从高到低排列的英雄
+	AramRerollEnabled     bool                  `json:"aram_reroll_enabled"`
+	AramRerollThreshold   int                   `json:"aram_reroll_threshold"`   // 当前英雄不在优先级列表前N名时重随
+
+	// 交换请求处理策略: off, accept, decline, auto
+	TradePolicy           string                `json:"trade_policy"`
+	PositionSwapPolicy    string                `json:"position_swap_policy"`
+	PickOrderSwapPolicy   string                `json:"pick_order_swap_policy"`
+	PositionPriority      []string              `json:"position_priority"`       // 按偏好从高到低排列的位置
+
+	// 选人阶段侦察队友
+	ScoutingEnabled       bool                  `json:"scouting_enabled"`
+
+	// 调试
+	RecordSessions        bool                  `json:"record_sessions"` // 将收到的LCU事件录制到数据目录下的recordings文件夹
+
+	// 客户端连接，无法通过进程获取凭据时使用
+	LeagueInstallPath     string                `json:"league_install_path"` // 英雄联盟安装目录，为空时自动检测
+	LCUHost               string                `json:"lcu_host"`            // LCU监听地址，为空时使用127.0.0.1
+	LCUPort               int                   `json:"lcu_port"`            // 手动指定的LCU端口
+	LCUToken              string                `json:"lcu_token"`           // 手动指定的LCU令牌
+	InsecureSkipTLSVerify bool                  `json:"insecure_skip_tls_verify"` // 跳过客户端证书校验，仅在证书校验失败时使用
+
+	// 自动接受对局
+	AutoAcceptDelay      int                    `json:"auto_accept_delay"`       // 接受前等待的秒数
+	AutoAcceptQueueIDs   []int                  `json:"auto_accept_queue_ids"`   // 仅对这些队列自动接受，为空表示全部
+	AwayModeEnabled      bool                   `json:"away_mode_enabled"`       // 离开模式，自动拒绝对局
+
+	// 自动重新排队
+	AutoRequeueEnabled     bool                 `json:"auto_requeue_enabled"`
+	AutoRequeueMaxAttempts int                  `json:"auto_requeue_max_attempts"` // 一局游戏开始前最多重新排队的次数
 }
 
+// maxAutoAcceptDelay 自动接受的最大延迟秒数，准备检查总共只有12秒
+const maxAutoAcceptDelay = 10
+
 // DefaultConfig 返回默认配置
 func DefaultConfig() *Config {
 	return &Config{
@@ -36,6 +91,31 @@ func DefaultConfig() *Config {
 			"BOTTOM":  nil,
 			"UTILITY": nil,
 		},
+		PositionBanChampions: map[string]*int{
+			"TOP":     nil,
+			"JUNGLE":  nil,
+			"MIDDLE":  nil,
+			"BOTTOM":  nil,
+			"UTILITY": nil,
+		},
+		BanBackupChampionIDs: []int{},
+		PositionChampionPools: map[string][]int{},
+		CounterPickMode:       CounterPickOff,
+		CompositionRules:      []CompositionRule{},
+		ChampionSpells:        map[string]SpellPair{},
+		PreferredSkins:        map[string]int{},
+		AramTierList:          []int{},
+		AramRerollThreshold:   10,
+		TradePolicy:           SwapPolicyOff,
+		PositionSwapPolicy:    SwapPolicyOff,
+		PickOrderSwapPolicy:   SwapPolicyOff,
+		PositionPriority:      []string{},
+		ScoutingEnabled:       true,
+		AutoAcceptDelay:     0,
+		AutoAcceptQueueIDs:  []int{},
+		AwayModeEnabled:     false,
+		AutoRequeueEnabled:     false,
+		AutoRequeueMaxAttempts: 3,
 	}
 }
 
@@ -72,6 +152,12 @@ func LoadConfig() (*Config, error) {
 			"UTILITY": nil,
 		}
 	}
+	if config.PositionBanChampions == nil {
+		config.PositionBanChampions = make(map[string]*int)
+	}
+	if config.PositionChampionPools == nil {
+		config.PositionChampionPools = make(map[string][]int)
+	}
 	
 	return config, nil
 }
@@ -108,6 +194,12 @@ func (c *Config) UpdateConfig(newConfig map[string]interface{}) error {
 		return fmt.Errorf("failed to unmarshal new config: %w", err)
 	}
 	
+	for _, rule := range tempConfig.CompositionRules {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid composition rule: %w", err)
+		}
+	}
+	
 	// 更新当前配置
 	c.AutoAcceptEnabled = tempConfig.AutoAcceptEnabled
 	c.PreselectEnabled = tempConfig.PreselectEnabled
@@ -116,6 +208,11 @@ func (c *Config) UpdateConfig(newConfig map[string]interface{}) error {
 	c.PreselectChampionID = tempConfig.PreselectChampionID
 	c.AutoBanChampionID = tempConfig.AutoBanChampionID
 	c.AutoPickChampionID = tempConfig.AutoPickChampionID
+	c.AutoAcceptDelay = tempConfig.AutoAcceptDelay
+	c.AutoAcceptQueueIDs = tempConfig.AutoAcceptQueueIDs
+	c.AwayModeEnabled = tempConfig.AwayModeEnabled
+	c.AutoRequeueEnabled = tempConfig.AutoRequeueEnabled
+	c.AutoRequeueMaxAttempts = tempConfig.AutoRequeueMaxAttempts
 	
 	// 更新位置英雄配置
 	if tempConfig.PositionChampions != nil {
@@ -127,9 +224,77 @@ func (c *Config) UpdateConfig(newConfig map[string]interface{}) error {
 		}
 	}
 	
+	// 更新位置Ban英雄配置
+	if tempConfig.PositionBanChampions != nil {
+		if c.PositionBanChampions == nil {
+			c.PositionBanChampions = make(map[string]*int)
+		}
+		for pos, champID := range tempConfig.PositionBanChampions {
+			c.PositionBanChampions[pos] = champID
+		}
+	}
+	c.BanBackupChampionIDs = tempConfig.BanBackupChampionIDs
+	if tempConfig.PositionChampionPools != nil {
+		c.PositionChampionPools = tempConfig.PositionChampionPools
+	}
+	c.CounterPickMode = tempConfig.CounterPickMode
+	c.CompositionRules = tempConfig.CompositionRules
+	c.PickOrderByMastery = tempConfig.PickOrderByMastery
+	c.PickOrderByWinRate = tempConfig.PickOrderByWinRate
+	c.RuneImportEnabled = tempConfig.RuneImportEnabled
+	c.SpellsEnabled = tempConfig.SpellsEnabled
+	if tempConfig.ChampionSpells != nil {
+		c.ChampionSpells = tempConfig.ChampionSpells
+	}
+	c.FlashOnF = tempConfig.FlashOnF
+	c.ItemSetsEnabled = tempConfig.ItemSetsEnabled
+	if tempConfig.PreferredSkins != nil {
+		c.PreferredSkins = tempConfig.PreferredSkins
+	}
+	c.AramEnabled = tempConfig.AramEnabled
+	c.AramTierList = tempConfig.AramTierList
+	c.AramRerollEnabled = tempConfig.AramRerollEnabled
+	c.AramRerollThreshold = tempConfig.AramRerollThreshold
+	c.TradePolicy = tempConfig.TradePolicy
+	c.PositionSwapPolicy = tempConfig.PositionSwapPolicy
+	c.PickOrderSwapPolicy = tempConfig.PickOrderSwapPolicy
+	c.PositionPriority = tempConfig.PositionPriority
+	c.ScoutingEnabled = tempConfig.ScoutingEnabled
+	c.RecordSessions = tempConfig.RecordSessions
+	c.LeagueInstallPath = tempConfig.LeagueInstallPath
+	c.LCUHost = tempConfig.LCUHost
+	c.LCUPort = tempConfig.LCUPort
+	c.LCUToken = tempConfig.LCUToken
+	c.InsecureSkipTLSVerify = tempConfig.InsecureSkipTLSVerify
+	
 	return nil
 }
 
+// GetAutoAcceptDelay 获取自动接受延迟，限制在准备检查的有效时间内
+func (c *Config) GetAutoAcceptDelay() time.Duration {
+	delay := c.AutoAcceptDelay
+	if delay < 0 {
+		delay = 0
+	}
+	if delay > maxAutoAcceptDelay {
+		delay = maxAutoAcceptDelay
+	}
+	return time.Duration(delay) * time.Second
+}
+
+// ShouldAutoAcceptQueue 判断指定队列是否需要自动接受
+func (c *Config) ShouldAutoAcceptQueue(queueID int) bool {
+	if len(c.AutoAcceptQueueIDs) == 0 {
+		return true
+	}
+	for _, id := range c.AutoAcceptQueueIDs {
+		if id == queueID {
+			return true
+		}
+	}
+	return false
+}
+
 // GetChampionIDForPosition 根据位置获取英雄ID
 func (c *Config) GetChampionIDForPosition(position string) *int {
 	if c.PositionChampions == nil {
@@ -138,4 +303,82 @@ func (c *Config) GetChampionIDForPosition(position string) *int {
 	// 将位置转换为大写以匹配配置中的键
 	position = strings.ToUpper(position)
 	return c.PositionChampions[position]
-}
\ No newline at end of file
+}
+
+// GetBanCandidates 获取按优先级排列的Ban英雄候选列表：位置Ban > 默认Ban > 替补
+func (c *Config) GetBanCandidates(position string) []int {
+	var candidates []int
+	seen := make(map[int]bool)
+	add := func(id *int) {
+		if id == nil || *id <= 0 || seen[*id] {
+			return
+		}
+		seen[*id] = true
+		candidates = append(candidates, *id)
+	}
+
+	if position != "" && c.PositionBanChampions != nil {
+		add(c.PositionBanChampions[strings.ToUpper(position)])
+	}
+	add(c.AutoBanChampionID)
+	for i := range c.BanBackupChampionIDs {
+		add(&c.BanBackupChampionIDs[i])
+	}
+
+	return candidates
+}
+
+// GetPickCandidates 获取按优先级排列的Pick英雄候选列表
+// 有位置时为位置英雄加该位置的英雄池，没有位置时为默认秒选英雄加DEFAULT英雄池
+func (c *Config) GetPickCandidates(position string) []int {
+	var candidates []int
+	seen := make(map[int]bool)
+	add := func(id int) {
+		if id <= 0 || seen[id] {
+			return
+		}
+		seen[id] = true
+		candidates = append(candidates, id)
+	}
+
+	poolKey := "DEFAULT"
+	if position != "" {
+		poolKey = strings.ToUpper(position)
+		if id := c.GetChampionIDForPosition(position); id != nil {
+			add(*id)
+		}
+	} else if c.AutoPickChampionID != nil {
+		add(*c.AutoPickChampionID)
+	}
+	for _, id := range c.PositionChampionPools[poolKey] {
+		add(id)
+	}
+
+	return candidates
+}
+
+// GetAramRank 获取英雄在极地大乱斗优先级列表中的排名，不在列表中时排在最后
+func (c *Config) GetAramRank(championID int) int {
+	for i, id := range c.AramTierList {
+		if id == championID {
+			return i
+		}
+	}
+	return len(c.AramTierList)
+}
+
+// ShouldAramReroll 判断极地大乱斗中的英雄是否需要重随，不在优先级列表中的英雄总是需要重随
+func (c *Config) ShouldAramReroll(championID int) bool {
+	rank := c.GetAramRank(championID)
+	return rank >= len(c.AramTierList) || rank >= c.AramRerollThreshold
+}
+
+// GetPositionRank 获取位置在位置偏好中的排名，不在列表中时排在最后
+func (c *Config) GetPositionRank(position string) int {
+	for i, p := range c.PositionPriority {
+		if strings.EqualFold(p, position) {
+			return i
+		}
+	}
+	return len(c.PositionPriority)
+}
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 克制关系的取值方式
+const (
+	CounterPickOff       = "off"
+	CounterPickPreselect = "preselect"
+	CounterPickLock      = "lock"
+)
+
+// Matchup 一条克制关系，Score为正表示Champion克制Opponent
+type Matchup struct {
+	ChampionID int     `json:"champion"`
+	OpponentID int     `json:"opponent"`
+	Position   string  `json:"position"` // 为空表示适用于所有位置
+	Score      float64 `json:"score"`
+}
+
+// matchupFile 克制关系JSON文件结构
+type matchupFile struct {
+	Matchups []Matchup `json:"matchups"`
+}
+
+// matchupKey 克制关系索引
+type matchupKey struct {
+	championID int
+	opponentID int
+	position   string
+}
+
+// MatchupTable 克制关系表
+type MatchupTable struct {
+	entries map[matchupKey]float64
+}
+
+// CounterPickSuggestion 克制选择建议
+type CounterPickSuggestion struct {
+	ChampionID int     `json:"championId"`
+	Score      float64 `json:"score"`
+	Opponents  []int   `json:"opponents"`
+	Reason     string  `json:"reason"`
+}
+
+// NewMatchupTable 创建空的克制关系表
+func NewMatchupTable() *MatchupTable {
+	return &MatchupTable{entries: make(map[matchupKey]float64)}
+}
+
+// GetMatchupsPath 获取克制关系文件路径，优先使用matchups.json，其次matchups.csv
+func GetMatchupsPath() (string, error) {
+	jsonPath, err := GetDataPath("matchups.json")
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(jsonPath); err == nil {
+		return jsonPath, nil
+	}
+	return GetDataPath("matchups.csv")
+}
+
+// LoadMatchupTable 从数据目录加载克制关系表，文件不存在时返回空表
+func LoadMatchupTable() (*MatchupTable, error) {
+	filename, err := GetMatchupsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get matchups path: %w", err)
+	}
+
+	table := NewMatchupTable()
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return table, nil
+		}
+		return nil, fmt.Errorf("failed to open matchups file: %w", err)
+	}
+	defer file.Close()
+
+	var matchups []Matchup
+	if strings.EqualFold(filepath.Ext(filename), ".csv") {
+		matchups, err = parseMatchupsCSV(file)
+	} else {
+		matchups, err = parseMatchupsJSON(file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse matchups file: %w", err)
+	}
+
+	for _, m := range matchups {
+		table.Add(m)
+	}
+	return table, nil
+}
+
+// parseMatchupsJSON 解析JSON格式的克制关系
+func parseMatchupsJSON(r io.Reader) ([]Matchup, error) {
+	var file matchupFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, err
+	}
+	return file.Matchups, nil
+}
+
+// parseMatchupsCSV 解析CSV格式的克制关系，列为 champion,opponent,position,score，首行可为表头
+func parseMatchupsCSV(r io.Reader) ([]Matchup, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.Comment = '#'
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	var matchups []Matchup
+	for i, record := range records {
+		if len(record) < 4 {
+			return nil, fmt.Errorf("line %d: expected 4 columns, got %d", i+1, len(record))
+		}
+		championID, err := strconv.Atoi(record[0])
+		if err != nil {
+			if i == 0 {
+				continue // 表头
+			}
+			return nil, fmt.Errorf("line %d: invalid champion id %q", i+1, record[0])
+		}
+		opponentID, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid opponent id %q", i+1, record[1])
+		}
+		score, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid score %q", i+1, record[3])
+		}
+		matchups = append(matchups, Matchup{
+			ChampionID: championID,
+			OpponentID: opponentID,
+			Position:   record[2],
+			Score:      score,
+		})
+	}
+	return matchups, nil
+}
+
+// Add 添加一条克制关系
+func (mt *MatchupTable) Add(m Matchup) {
+	key := matchupKey{
+		championID: m.ChampionID,
+		opponentID: m.OpponentID,
+		position:   strings.ToUpper(m.Position),
+	}
+	mt.entries[key] = m.Score
+}
+
+// Len 克制关系数量
+func (mt *MatchupTable) Len() int {
+	return len(mt.entries)
+}
+
+// Score 查询英雄对阵对手的分数，优先使用位置相关的数据，
+// 没有正向数据时使用反向数据取负值
+func (mt *MatchupTable) Score(championID, opponentID int, position string) (float64, bool) {
+	position = strings.ToUpper(position)
+	lookups := []struct {
+		key  matchupKey
+		sign float64
+	}{
+		{matchupKey{championID, opponentID, position}, 1},
+		{matchupKey{championID, opponentID, ""}, 1},
+		{matchupKey{opponentID, championID, position}, -1},
+		{matchupKey{opponentID, championID, ""}, -1},
+	}
+	for _, lookup := range lookups {
+		if score, ok := mt.entries[lookup.key]; ok {
+			return score * lookup.sign, true
+		}
+	}
+	return 0, false
+}
+
+// Rank 根据对手为候选英雄排序，分数相同时保持配置顺序
+func (mt *MatchupTable) Rank(candidates []int, opponents []int, position string) []CounterPickSuggestion {
+	suggestions := make([]CounterPickSuggestion, 0, len(candidates))
+	for _, championID := range candidates {
+		suggestion := CounterPickSuggestion{
+			ChampionID: championID,
+			Opponents:  opponents,
+		}
+
+		var total float64
+		var known int
+		var reasons []string
+		for _, opponentID := range opponents {
+			score, ok := mt.Score(championID, opponentID, position)
+			if !ok {
+				continue
+			}
+			total += score
+			known++
+			reasons = append(reasons, fmt.Sprintf("vs %d: %+.1f", opponentID, score))
+		}
+
+		if known > 0 {
+			suggestion.Score = total / float64(known)
+			suggestion.Reason = strings.Join(reasons, ", ")
+		} else {
+			suggestion.Reason = "no matchup data"
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	return suggestions
+}
@@ -0,0 +1,130 @@
+package main
+
+import "time"
+
+// eogStatsBlock /lol-end-of-game/v1/eog-stats-block 的响应
+type eogStatsBlock struct {
+	GameID      int64     `json:"gameId"`
+	GameLength  int       `json:"gameLength"` // 单位秒
+	GameMode    string    `json:"gameMode"`
+	QueueType   string    `json:"queueType"`
+	LocalPlayer eogPlayer `json:"localPlayer"`
+	Teams       []eogTeam `json:"teams"`
+}
+
+// eogTeam 结算数据中的队伍
+type eogTeam struct {
+	TeamID        int         `json:"teamId"`
+	IsWinningTeam bool        `json:"isWinningTeam"`
+	IsPlayerTeam  bool        `json:"isPlayerTeam"`
+	Players       []eogPlayer `json:"players"`
+}
+
+// eogPlayer 结算数据中的玩家，统计项的键为大写的统计名
+type eogPlayer struct {
+	PUUID          string             `json:"puuid"`
+	SummonerName   string             `json:"summonerName"`
+	RiotIDGameName string             `json:"riotIdGameName"`
+	ChampionID     int                `json:"championId"`
+	ChampionName   string             `json:"championName"`
+	TeamID         int                `json:"teamId"`
+	Stats          map[string]float64 `json:"stats"`
+}
+
+// PlayerSummary 一名玩家的对局数据
+type PlayerSummary struct {
+	Name         string `json:"name"`
+	PUUID        string `json:"puuid"`
+	ChampionID   int    `json:"champion_id"`
+	ChampionName string `json:"champion_name"`
+	Kills        int    `json:"kills"`
+	Deaths       int    `json:"deaths"`
+	Assists      int    `json:"assists"`
+	CreepScore   int    `json:"creep_score"`
+	Damage       int    `json:"damage"` // 对英雄造成的伤害
+	Gold         int    `json:"gold"`
+}
+
+// KDA 计算 (击杀+助攻)/死亡，没有死亡时按1计算
+func (p PlayerSummary) KDA() float64 {
+	deaths := p.Deaths
+	if deaths == 0 {
+		deaths = 1
+	}
+	return float64(p.Kills+p.Assists) / float64(deaths)
+}
+
+// TeamSummary 一支队伍的对局数据
+type TeamSummary struct {
+	TeamID       int             `json:"team_id"`
+	Win          bool            `json:"win"`
+	IsPlayerTeam bool            `json:"is_player_team"`
+	Kills        int             `json:"kills"`
+	Players      []PlayerSummary `json:"players"`
+}
+
+// GameSummary 一局游戏的结算汇总
+type GameSummary struct {
+	GameID     int64         `json:"game_id"`
+	GameMode   string        `json:"game_mode"`
+	QueueType  string        `json:"queue_type"`
+	GameLength int           `json:"game_length"` // 单位秒
+	EndedAt    time.Time     `json:"ended_at"`
+	Win        bool          `json:"win"`
+	Player     PlayerSummary `json:"player"`
+	Teams      []TeamSummary `json:"teams"`
+	LPChange   *int          `json:"lp_change,omitempty"` // 仅排位赛有值
+}
+
+// newPlayerSummary 从结算数据中提取玩家数据
+func newPlayerSummary(player eogPlayer) PlayerSummary {
+	name := player.RiotIDGameName
+	if name == "" {
+		name = player.SummonerName
+	}
+	return PlayerSummary{
+		Name:         name,
+		PUUID:        player.PUUID,
+		ChampionID:   player.ChampionID,
+		ChampionName: player.ChampionName,
+		Kills:        int(player.Stats["CHAMPIONS_KILLED"]),
+		Deaths:       int(player.Stats["NUM_DEATHS"]),
+		Assists:      int(player.Stats["ASSISTS"]),
+		CreepScore:   int(player.Stats["MINIONS_KILLED"] + player.Stats["NEUTRAL_MINIONS_KILLED"]),
+		Damage:       int(player.Stats["TOTAL_DAMAGE_DEALT_TO_CHAMPIONS"]),
+		Gold:         int(player.Stats["GOLD_EARNED"]),
+	}
+}
+
+// newGameSummary 将结算数据整理为对局汇总
+func newGameSummary(block *eogStatsBlock) *GameSummary {
+	summary := &GameSummary{
+		GameID:     block.GameID,
+		GameMode:   block.GameMode,
+		QueueType:  block.QueueType,
+		GameLength: block.GameLength,
+		EndedAt:    time.Now(),
+		Player:     newPlayerSummary(block.LocalPlayer),
+		Teams:      make([]TeamSummary, 0, len(block.Teams)),
+	}
+
+	for _, team := range block.Teams {
+		teamSummary := TeamSummary{
+			TeamID:       team.TeamID,
+			Win:          team.IsWinningTeam,
+			IsPlayerTeam: team.IsPlayerTeam,
+			Players:      make([]PlayerSummary, 0, len(team.Players)),
+		}
+		for _, player := range team.Players {
+			playerSummary := newPlayerSummary(player)
+			teamSummary.Kills += playerSummary.Kills
+			teamSummary.Players = append(teamSummary.Players, playerSummary)
+		}
+		if team.IsPlayerTeam {
+			summary.Win = team.IsWinningTeam
+		}
+		summary.Teams = append(summary.Teams, teamSummary)
+	}
+
+	return summary
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// maxHistoryGames 本地保存的最大对局数
+const maxHistoryGames = 200
+
+// GameRecord 一局游戏的本地记录
+type GameRecord struct {
+	GameID    int64             `json:"game_id,omitempty"`
+	StartedAt time.Time         `json:"started_at"`
+	EndedAt   time.Time         `json:"ended_at"`
+	Live      *LiveGameSnapshot `json:"live,omitempty"`    // 对局结束前最后一次游戏内数据
+	Summary   *GameSummary      `json:"summary,omitempty"` // 结算数据
+}
+
+// GameHistory 本地对局记录，按结束时间从早到晚排列
+type GameHistory struct {
+	Games  []GameRecord `json:"games"`
+	path   string       // 为空时只保存在内存中
+	mu     sync.RWMutex
+	saveMu sync.Mutex // 保证文件按保存顺序写入
+}
+
+// GetHistoryPath 获取对局记录文件的完整路径
+func GetHistoryPath() (string, error) {
+	return GetDataPath("history.json")
+}
+
+// NewGameHistory 创建只保存在内存中的对局记录
+func NewGameHistory() *GameHistory {
+	return &GameHistory{Games: []GameRecord{}}
+}
+
+// LoadGameHistory 从文件加载对局记录
+func LoadGameHistory() (*GameHistory, error) {
+	filename, err := GetHistoryPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history path: %w", err)
+	}
+
+	history := NewGameHistory()
+	history.path = filename
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return history, nil
+		}
+		return nil, fmt.Errorf("failed to read history file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, history); err != nil {
+		return nil, fmt.Errorf("failed to parse history file: %w", err)
+	}
+	if history.Games == nil {
+		history.Games = []GameRecord{}
+	}
+
+	return history, nil
+}
+
+// Save 保存对局记录到文件
+func (h *GameHistory) Save() error {
+	if h.path == "" {
+		return nil
+	}
+
+	h.saveMu.Lock()
+	defer h.saveMu.Unlock()
+
+	h.mu.RLock()
+	data, err := json.MarshalIndent(h, "", "  ")
+	h.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal history: %w", err)
+	}
+
+	if err := writeFileAtomic(h.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write history file: %w", err)
+	}
+
+	return nil
+}
+
+// Update 查找指定对局的记录并修改，不存在时新建，超出上限时丢弃最早的记录
+func (h *GameHistory) Update(gameID int64, update func(record *GameRecord)) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if gameID != 0 {
+		for i := len(h.Games) - 1; i >= 0; i-- {
+			if h.Games[i].GameID == gameID {
+				update(&h.Games[i])
+				return
+			}
+		}
+	}
+
+	record := GameRecord{GameID: gameID}
+	update(&record)
+	h.Games = append(h.Games, record)
+	if len(h.Games) > maxHistoryGames {
+		h.Games = h.Games[len(h.Games)-maxHistoryGames:]
+	}
+}
+
+// RecentSummaries 获取最近n局有结算数据的对局汇总，最新的在前，n<=0时返回全部
+func (h *GameHistory) RecentSummaries(n int) []GameSummary {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	summaries := []GameSummary{}
+	for i := len(h.Games) - 1; i >= 0; i-- {
+		if n > 0 && len(summaries) >= n {
+			break
+		}
+		if h.Games[i].Summary != nil {
+			summaries = append(summaries, *h.Games[i].Summary)
+		}
+	}
+	return summaries
+}
+
+// Recent 获取最近的n局记录，最新的在前
+func (h *GameHistory) Recent(n int) []GameRecord {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if n <= 0 || n > len(h.Games) {
+		n = len(h.Games)
+	}
+	records := make([]GameRecord, 0, n)
+	for i := len(h.Games) - 1; i >= 0 && len(records) < n; i-- {
+		records = append(records, h.Games[i])
+	}
+	return records
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestGameHistoryConcurrentSave(t *testing.T) {
+	dir := t.TempDir()
+	history := NewGameHistory()
+	history.path = filepath.Join(dir, "history.json")
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(gameID int64) {
+			defer wg.Done()
+			history.Update(gameID, func(record *GameRecord) {
+				record.Summary = &GameSummary{GameID: gameID}
+			})
+			if err := history.Save(); err != nil {
+				t.Errorf("Save() error = %v", err)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(history.path)
+	if err != nil {
+		t.Fatalf("failed to read history file: %v", err)
+	}
+	saved := &GameHistory{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		t.Fatalf("history file is not valid JSON: %v", err)
+	}
+	if len(saved.Games) != 20 {
+		t.Errorf("saved %d games, want 20", len(saved.Games))
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("data directory has %d entries, want only history.json", len(entries))
+	}
+}
+
+func TestRecordLiveGameSkipsUnknownGameID(t *testing.T) {
+	history := NewGameHistory()
+	history.Update(42, func(record *GameRecord) {})
+	app := &App{history: history}
+
+	app.recordLiveGame(&LiveGameSnapshot{GameID: 0, GameTime: 600})
+	if len(history.Games) != 1 {
+		t.Fatalf("history has %d records after unknown game id, want 1", len(history.Games))
+	}
+	if history.Games[0].Live != nil {
+		t.Errorf("record 42 got a live snapshot from an unknown game")
+	}
+
+	app.recordLiveGame(&LiveGameSnapshot{GameID: 42, GameTime: 600})
+	if len(history.Games) != 1 || history.Games[0].Live == nil {
+		t.Errorf("history = %+v, want record 42 with a live snapshot", history.Games)
+	}
+}
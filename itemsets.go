@@ -0,0 +1,166 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// ItemSetItem 出装方案中的物品
+type ItemSetItem struct {
+	ID    string `json:"id"`
+	Count int    `json:"count"`
+}
+
+// ItemSetBlock 出装方案中的物品分组，如"出门装"、"核心装备"
+type ItemSetBlock struct {
+	Type  string        `json:"type"`
+	Items []ItemSetItem `json:"items"`
+}
+
+// ItemSetTemplate 出装方案模板
+type ItemSetTemplate struct {
+	Title  string         `json:"title"`
+	Patch  string         `json:"patch"` // 保存模板时的游戏版本
+	Blocks []ItemSetBlock `json:"blocks"`
+}
+
+// ItemSetLibrary 按英雄和位置存储的出装方案模板库
+type ItemSetLibrary struct {
+	Templates map[string]ItemSetTemplate `json:"templates"` // 键为 "英雄ID" 或 "英雄ID:位置"
+	mu        sync.RWMutex
+	saveMu    sync.Mutex // 保证文件按保存顺序写入
+}
+
+// Validate 检查模板是否有效
+func (t ItemSetTemplate) Validate() error {
+	if len(t.Blocks) == 0 {
+		return fmt.Errorf("item set requires at least one block")
+	}
+	for _, block := range t.Blocks {
+		if len(block.Items) == 0 {
+			return fmt.Errorf("item set block %q has no items", block.Type)
+		}
+	}
+	return nil
+}
+
+// IsOutdated 判断模板是否来自其他大版本，只比较版本号的前两段
+func (t ItemSetTemplate) IsOutdated(version string) bool {
+	if t.Patch == "" || version == "" {
+		return false
+	}
+	return majorMinor(t.Patch) != majorMinor(version)
+}
+
+// majorMinor 获取版本号的前两段，如 14.20.1 -> 14.20
+func majorMinor(version string) string {
+	parts := strings.SplitN(version, ".", 3)
+	if len(parts) < 2 {
+		return version
+	}
+	return parts[0] + "." + parts[1]
+}
+
+// GetItemSetsPath 获取出装方案模板库文件的完整路径
+func GetItemSetsPath() (string, error) {
+	return GetDataPath("itemsets.json")
+}
+
+// NewItemSetLibrary 创建空的出装方案模板库
+func NewItemSetLibrary() *ItemSetLibrary {
+	return &ItemSetLibrary{Templates: make(map[string]ItemSetTemplate)}
+}
+
+// LoadItemSetLibrary 从文件加载出装方案模板库
+func LoadItemSetLibrary() (*ItemSetLibrary, error) {
+	library := NewItemSetLibrary()
+
+	filename, err := GetItemSetsPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get item sets path: %w", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return library, nil
+		}
+		return nil, fmt.Errorf("failed to read item sets file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, library); err != nil {
+		return nil, fmt.Errorf("failed to parse item sets file: %w", err)
+	}
+	if library.Templates == nil {
+		library.Templates = make(map[string]ItemSetTemplate)
+	}
+
+	return library, nil
+}
+
+// Save 保存出装方案模板库到文件
+func (il *ItemSetLibrary) Save() error {
+	filename, err := GetItemSetsPath()
+	if err != nil {
+		return fmt.Errorf("failed to get item sets path: %w", err)
+	}
+
+	il.saveMu.Lock()
+	defer il.saveMu.Unlock()
+
+	il.mu.RLock()
+	data, err := json.MarshalIndent(il, "", "  ")
+	il.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal item sets: %w", err)
+	}
+
+	if err := writeFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write item sets file: %w", err)
+	}
+
+	return nil
+}
+
+// Get 获取英雄在指定位置的出装方案，没有位置专用的方案时使用通用方案
+func (il *ItemSetLibrary) Get(championID int, position string) (ItemSetTemplate, bool) {
+	il.mu.RLock()
+	defer il.mu.RUnlock()
+
+	if position != "" {
+		if template, ok := il.Templates[championPositionKey(championID, position)]; ok {
+			return template, true
+		}
+	}
+	template, ok := il.Templates[championPositionKey(championID, "")]
+	return template, ok
+}
+
+// Set 设置英雄在指定位置的出装方案
+func (il *ItemSetLibrary) Set(championID int, position string, template ItemSetTemplate) {
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	il.Templates[championPositionKey(championID, position)] = template
+}
+
+// Delete 删除英雄在指定位置的出装方案
+func (il *ItemSetLibrary) Delete(championID int, position string) {
+	il.mu.Lock()
+	defer il.mu.Unlock()
+	delete(il.Templates, championPositionKey(championID, position))
+}
+
+// All 获取所有出装方案的副本
+func (il *ItemSetLibrary) All() map[string]ItemSetTemplate {
+	il.mu.RLock()
+	defer il.mu.RUnlock()
+
+	templates := make(map[string]ItemSetTemplate, len(il.Templates))
+	for key, template := range il.Templates {
+		templates[key] = template
+	}
+	return templates
+}
@@ -1,21 +1,11 @@
 package main
 
 import (
-	"bytes"
-	"crypto/tls"
-	"encoding/base64"
 	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
-
-	"github.com/ImOlli/go-lcu/lcu"
-	"github.com/gorilla/websocket"
 )
 
 // LCUCredentials LCU连接凭据
@@ -23,20 +13,35 @@ type LCUCredentials struct {
 	Port     int
 	Token    string
 	Protocol string
+	Source   string // 提供凭据的来源
 }
 
 // LCUStatus LCU连接状态
 type LCUStatus struct {
-	Connected    bool                   `json:"connected"`
-	ClientStatus string                 `json:"client_status"`
-	ChampSelect  map[string]interface{} `json:"champ_select"`
+	Connected        bool                   `json:"connected"`
+	ClientStatus     string                 `json:"client_status"`
+	ChampSelect      map[string]interface{} `json:"champ_select"`
+	PickIssue        string                 `json:"pick_issue,omitempty"`        // 无法自动选择英雄的原因
+	CredentialSource string                 `json:"credential_source,omitempty"` // 凭据来源: process, lockfile, explicit
+}
+
+// Connector App依赖的LCU连接器接口
+type Connector interface {
+	Connect() error
+	Disconnect()
+	IsConnected() bool
+	GetStatus() *LCUStatus
+	GetCounterPicks() []CounterPickSuggestion
+	Request(method, path string, body interface{}) (map[string]interface{}, error)
+	RequestJSON(method, path string, body interface{}, out interface{}) error
 }
 
 // LCUConnector LCU连接器
 type LCUConnector struct {
+	host        string
 	credentials *LCUCredentials
-	client      *http.Client
-	ws          *websocket.Conn
+	transport   RESTTransport
+	events      EventStream
 	status      *LCUStatus
 	statusLock  sync.RWMutex
 	connected   bool
@@ -44,26 +49,54 @@ type LCUConnector struct {
 	stopChan    chan struct{}
 	app         *App // 引用主应用
 
+	// 异步执行和延迟，回放录制的会话时替换为同步执行
+	spawn func(func())
+	sleep func(time.Duration)
+	// 可取消的等待，cancel关闭时提前返回false
+	wait func(d time.Duration, cancel <-chan struct{}) bool
+
+	// 录制收到的WebSocket消息
+	recorder *SessionRecorder
+
+	// 凭据提供者链，为空时使用默认提供者
+	providers []CredentialProvider
+
 	// 跟踪已处理的操作
 	processedActions map[string]bool
 	actionLock       sync.RWMutex
 
 	// 跟踪状态
 	lastPreselectChampion *int
-	readyCheckAccepted    bool
 	loggedWarnings        map[string]bool
 	warningLock           sync.RWMutex
+
+	// 准备检查状态，每次新的准备检查都会递增readyCheckSeq
+	readyCheckSeq       int
+	readyCheckResponded bool
+	readyCheckLock      sync.Mutex
+
+	// 自动重新排队状态，关闭requeueCancel即取消等待中的重新排队
+	requeueAttempts int
+	requeueCancel   chan struct{}
+	requeueLock     sync.Mutex
+
+	// 当前英雄选择的克制建议
+	counterPicks    []CounterPickSuggestion
+	counterPickLock sync.RWMutex
+
+	// 本次英雄选择中可用的英雄，nil表示未知
+	ownedChampions    map[int]bool
+	disabledChampions map[int]bool
+	availabilityLock  sync.RWMutex
+
+	// 选人阶段侦察
+	scout scoutState
 }
 
-// NewLCUConnector 创建新的LCU连接器
-func NewLCUConnector(app *App) *LCUConnector {
-	return &LCUConnector{
-		client: &http.Client{
-			Timeout: 10 * time.Second,
-			Transport: &http.Transport{
-				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-			},
-		},
+// NewLCUConnector 创建新的LCU连接器，默认使用HTTP和WebSocket连接本机客户端
+func NewLCUConnector(app *App, opts ...LCUOption) *LCUConnector {
+	lcu := &LCUConnector{
+		host: lcuHost(app.config),
 		status: &LCUStatus{
 			Connected:    false,
 			ClientStatus: "unknown",
@@ -71,35 +104,59 @@ func NewLCUConnector(app *App) *LCUConnector {
 		},
 		stopChan:         make(chan struct{}),
 		app:              app,
+		spawn:            func(f func()) { go f() },
+		sleep:            time.Sleep,
+		wait:             waitOrCancel,
 		processedActions: make(map[string]bool),
 		loggedWarnings:   make(map[string]bool),
 	}
-}
 
-// findLCUCredentials 查找LCU连接凭据
-func (lcuConn *LCUConnector) findLCUCredentials() (*LCUCredentials, error) {
-	// 使用go-lcu库自动获取LCU连接信息 <mcreference link="https://pkg.go.dev/github.com/ImOlli/go-lcu/lcu" index="1">1</mcreference>
-	info, err := lcu.FindLCUConnectInfo()
-	if err != nil {
-		if lcu.IsProcessNotFoundError(err) {
-			fmt.Println("[ERROR] LeagueClientUx.exe进程未找到")
-			return nil, fmt.Errorf("LeagueClientUx.exe process not found - League client may not be running")
+	for _, opt := range opts {
+		opt(lcu)
+	}
+
+	// 未注入传输层或事件流时，按连接地址创建默认实现
+	if lcu.transport == nil || lcu.events == nil {
+		tlsConfig := riotTLSConfig(lcu.host, skipTLSVerify(app.config))
+		if lcu.transport == nil {
+			lcu.transport = NewHTTPTransport(lcu.host, tlsConfig)
 		}
-		fmt.Printf("[ERROR] 获取LCU连接信息失败: %v\n", err)
-		return nil, fmt.Errorf("failed to find LCU credentials: %w", err)
+		if lcu.events == nil {
+			lcu.events = NewWebSocketStream(lcu.host, tlsConfig)
+		}
+	}
+
+	return lcu
+}
+
+// waitOrCancel 等待指定时间，cancel先关闭时返回false
+func waitOrCancel(d time.Duration, cancel <-chan struct{}) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-cancel:
+		return false
+	}
+}
+
+// findLCUCredentials 依次通过各个凭据提供者查找LCU连接凭据
+func (lcu *LCUConnector) findLCUCredentials() (*LCUCredentials, error) {
+	providers := lcu.providers
+	if providers == nil {
+		providers = defaultCredentialProviders(lcu.app.config)
 	}
 
-	// 转换端口为整数
-	port, err := strconv.Atoi(info.Port)
+	creds, err := findCredentials(providers)
 	if err != nil {
-		return nil, fmt.Errorf("invalid port number: %s", info.Port)
+		fmt.Printf("[ERROR] 获取LCU连接信息失败: %v\n", err)
+		return nil, err
 	}
 
-	return &LCUCredentials{
-		Port:     port,
-		Token:    info.AuthToken,
-		Protocol: "https",
-	}, nil
+	fmt.Printf("[INFO] LCU credentials found via %s\n", creds.Source)
+	return creds, nil
 }
 
 // Connect 连接到LCU
@@ -111,6 +168,10 @@ func (lcu *LCUConnector) Connect() error {
 
 	lcu.credentials = creds
 
+	lcu.statusLock.Lock()
+	lcu.status.CredentialSource = creds.Source
+	lcu.statusLock.Unlock()
+
 	// 测试HTTP连接
 	if err := lcu.testConnection(); err != nil {
 		return fmt.Errorf("failed to test LCU connection: %w", err)
@@ -123,6 +184,7 @@ func (lcu *LCUConnector) Connect() error {
 
 	lcu.setConnected(true)
 	lcu.updateStatus()
+	lcu.spawn(lcu.loadChampionMastery)
 
 	fmt.Println("[INFO] LCU API is ready to be used.")
 	fmt.Println("[INFO] 🚀 后端服务启动成功！")
@@ -136,27 +198,15 @@ func (lcu *LCUConnector) testConnection() error {
 		return fmt.Errorf("not connected to LCU")
 	}
 
-	url := fmt.Sprintf("https://127.0.0.1:%d/lol-gameflow/v1/gameflow-phase", lcu.credentials.Port)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		fmt.Printf("[ERROR] 创建HTTP请求失败: %v\n", err)
-		return err
-	}
-
-	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("riot:%s", lcu.credentials.Token)))
-	req.Header.Set("Authorization", "Basic "+auth)
-
-	resp, err := lcu.client.Do(req)
+	status, _, err := lcu.transport.Do(lcu.credentials, "GET", "/lol-gameflow/v1/gameflow-phase", nil)
 	if err != nil {
 		fmt.Printf("[ERROR] HTTP请求失败: %v\n", err)
 		return err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		fmt.Printf("[ERROR] LCU API返回错误状态码: %d\n", resp.StatusCode)
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	if status < 200 || status >= 300 {
+		fmt.Printf("[ERROR] LCU API返回错误状态码: %d\n", status)
+		return fmt.Errorf("HTTP %d", status)
 	}
 
 	return nil
@@ -164,35 +214,21 @@ func (lcu *LCUConnector) testConnection() error {
 
 // connectWebSocket 连接WebSocket
 func (lcu *LCUConnector) connectWebSocket() error {
-	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("riot:%s", lcu.credentials.Token)))
-	header := http.Header{}
-	header.Add("Authorization", "Basic "+auth)
-
-	u := url.URL{
-		Scheme: "wss",
-		Host:   fmt.Sprintf("127.0.0.1:%d", lcu.credentials.Port),
-		Path:   "/",
-	}
-
-	dialer := websocket.Dialer{
-		TLSClientConfig:  &tls.Config{InsecureSkipVerify: true},
-		Subprotocols:     []string{"wamp"},
-		HandshakeTimeout: 10 * time.Second,
+	if err := lcu.events.Open(lcu.credentials); err != nil {
+		return err
 	}
 
-	ws, resp, err := dialer.Dial(u.String(), header)
-	if err != nil {
-		fmt.Printf("[ERROR] WebSocket连接失败: %v\n", err)
-		if resp != nil {
-			fmt.Printf("[ERROR] WebSocket响应状态: %s\n", resp.Status)
-			body, _ := io.ReadAll(resp.Body)
-			fmt.Printf("[ERROR] WebSocket响应体: %s\n", string(body))
+	// 开启录制时将收到的消息写入文件
+	if lcu.app.config.RecordSessions {
+		recorder, err := NewSessionRecorder()
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to start session recording: %v\n", err)
+		} else {
+			lcu.recorder = recorder
+			fmt.Printf("[INFO] Recording LCU events to %s\n", recorder.Path())
 		}
-		return err
 	}
 
-	lcu.ws = ws
-
 	// 启动消息处理循环
 	go lcu.handleWebSocketMessages()
 
@@ -209,8 +245,7 @@ func (lcu *LCUConnector) connectWebSocket() error {
 func (lcu *LCUConnector) subscribeToEvents() {
 	// LCU WebSocket使用WAMP 1.0协议
 	// 订阅OnJsonApiEvent来接收所有JSON API事件
-	msg := []interface{}{5, "OnJsonApiEvent"}
-	if err := lcu.ws.WriteJSON(msg); err != nil {
+	if err := lcu.events.Subscribe("OnJsonApiEvent"); err != nil {
 		fmt.Printf("[ERROR] Failed to subscribe to OnJsonApiEvent: %v\n", err)
 	}
 }
@@ -218,19 +253,16 @@ func (lcu *LCUConnector) subscribeToEvents() {
 // handleWebSocketMessages 处理WebSocket消息
 func (lcu *LCUConnector) handleWebSocketMessages() {
 	defer func() {
-		if lcu.ws != nil {
-			lcu.ws.Close()
-			lcu.ws = nil
+		lcu.events.Close()
+		if lcu.recorder != nil {
+			lcu.recorder.Close()
 		}
 		lcu.setConnected(false)
 	}()
 
-	// 设置读取超时
-	lcu.ws.SetReadDeadline(time.Time{}) // 无限期等待
-
 	for {
-		var msg json.RawMessage
-		if err := lcu.ws.ReadJSON(&msg); err != nil {
+		msg, err := lcu.events.Read()
+		if err != nil {
 			// 只在非EOF错误时打印错误信息
 			if !strings.Contains(err.Error(), "EOF") && !strings.Contains(err.Error(), "close") {
 				fmt.Printf("[ERROR] WebSocket read error: %v\n", err)
@@ -238,6 +270,10 @@ func (lcu *LCUConnector) handleWebSocketMessages() {
 			return
 		}
 
+		if lcu.recorder != nil {
+			lcu.recorder.Record(msg)
+		}
+
 		// 解析JSON消息
 		var parsedMsg []interface{}
 		if err := json.Unmarshal(msg, &parsedMsg); err != nil {
@@ -286,46 +322,40 @@ func (lcu *LCUConnector) handleEvent(msg []interface{}) {
 	}
 }
 
-// request 发送HTTP请求到LCU API
-func (lcu *LCUConnector) request(method, path string, body interface{}) (map[string]interface{}, error) {
+// requestRaw 发送HTTP请求到LCU API并返回原始响应体
+func (lcu *LCUConnector) requestRaw(method, path string, body interface{}) ([]byte, error) {
 	if lcu.credentials == nil {
 		return nil, fmt.Errorf("not connected to LCU")
 	}
 
-	var reqBody io.Reader
+	var reqBody []byte
 	if body != nil {
 		jsonData, err := json.Marshal(body)
 		if err != nil {
 			return nil, err
 		}
-		reqBody = bytes.NewReader(jsonData)
+		reqBody = jsonData
 	}
 
-	url := fmt.Sprintf("https://127.0.0.1:%d%s", lcu.credentials.Port, path)
-	req, err := http.NewRequest(method, url, reqBody)
+	status, respBody, err := lcu.transport.Do(lcu.credentials, method, path, reqBody)
 	if err != nil {
 		return nil, err
 	}
 
-	auth := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("riot:%s", lcu.credentials.Token)))
-	req.Header.Set("Authorization", "Basic "+auth)
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := lcu.client.Do(req)
-	if err != nil {
-		return nil, err
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("HTTP %d: %s", status, string(respBody))
 	}
-	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	return respBody, nil
+}
+
+// request 发送HTTP请求到LCU API
+func (lcu *LCUConnector) request(method, path string, body interface{}) (map[string]interface{}, error) {
+	respBody, err := lcu.requestRaw(method, path, body)
 	if err != nil {
 		return nil, err
 	}
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, string(respBody))
-	}
-
 	var result map[string]interface{}
 	if len(respBody) > 0 {
 		// 尝试解析为JSON对象
@@ -344,6 +374,34 @@ func (lcu *LCUConnector) request(method, path string, body interface{}) (map[str
 	return result, nil
 }
 
+// requestJSON 发送HTTP请求到LCU API并将响应解析到out中，适用于数组等非对象响应
+func (lcu *LCUConnector) requestJSON(method, path string, body interface{}, out interface{}) error {
+	respBody, err := lcu.requestRaw(method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to parse response of %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Request 发送HTTP请求到LCU API，供App调用
+func (lcu *LCUConnector) Request(method, path string, body interface{}) (map[string]interface{}, error) {
+	return lcu.request(method, path, body)
+}
+
+// RequestJSON 发送HTTP请求到LCU API并将响应解析到out中，供App调用
+func (lcu *LCUConnector) RequestJSON(method, path string, body interface{}, out interface{}) error {
+	return lcu.requestJSON(method, path, body, out)
+}
+
 // setConnected 设置连接状态
 func (lcu *LCUConnector) setConnected(connected bool) {
 	lcu.connLock.Lock()
@@ -356,6 +414,7 @@ func (lcu *LCUConnector) setConnected(connected bool) {
 	if !connected {
 		lcu.status.ClientStatus = "unknown"
 		lcu.status.ChampSelect = nil
+		lcu.status.PickIssue = ""
 	}
 }
 
@@ -373,8 +432,10 @@ func (lcu *LCUConnector) GetStatus() *LCUStatus {
 
 	// 创建状态副本
 	status := &LCUStatus{
-		Connected:    lcu.status.Connected,
-		ClientStatus: lcu.status.ClientStatus,
+		Connected:        lcu.status.Connected,
+		ClientStatus:     lcu.status.ClientStatus,
+		PickIssue:        lcu.status.PickIssue,
+		CredentialSource: lcu.status.CredentialSource,
 	}
 
 	if lcu.status.ChampSelect != nil {
@@ -404,18 +465,30 @@ func (lcu *LCUConnector) updateStatus() {
 		lcu.statusLock.Lock()
 		lcu.status.ClientStatus = phase
 		lcu.statusLock.Unlock()
+
+		// 在对局中启动时直接开始轮询游戏内数据
+		if phase == "InProgress" {
+			lcu.app.startLiveGame(lcu.getCurrentGameID())
+		}
 	}
 }
 
 // Disconnect 断开连接
 func (lcu *LCUConnector) Disconnect() {
 	lcu.setConnected(false)
+	lcu.cancelRequeue()
 
-	if lcu.ws != nil {
-		lcu.ws.Close()
-		lcu.ws = nil
+	lcu.statusLock.Lock()
+	lcu.status.CredentialSource = ""
+	lcu.statusLock.Unlock()
+
+	if lcu.recorder != nil {
+		lcu.recorder.Close()
+		lcu.recorder = nil
 	}
 
+	lcu.events.Close()
+
 	// 安全关闭stopChan
 	select {
 	case <-lcu.stopChan:
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+)
+
+// handleAramBench 极地大乱斗中按优先级从备选席交换英雄，当前英雄不够好时使用重随
+func (lcu *LCUConnector) handleAramBench(data map[string]interface{}, localCellID int) {
+	config := lcu.app.config
+	if len(config.AramTierList) == 0 {
+		warningKey := "no_aram_tier_list"
+		if !lcu.isWarningLogged(warningKey) {
+			fmt.Println("[INFO] No ARAM tier list configured")
+			lcu.addLoggedWarning(warningKey)
+		}
+		return
+	}
+
+	current := lcu.getLockedChampion(data, localCellID)
+	if current <= 0 {
+		return
+	}
+	currentRank := config.GetAramRank(current)
+
+	// 从备选席中找出比当前英雄优先级更高的英雄
+	bestBench, bestRank := -1, currentRank
+	if bench, ok := data["benchChampions"].([]interface{}); ok {
+		for _, entry := range bench {
+			benchChamp, ok := entry.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			championID, _ := benchChamp["championId"].(float64)
+			if championID <= 0 {
+				continue
+			}
+			if rank := config.GetAramRank(int(championID)); rank < bestRank {
+				bestBench, bestRank = int(championID), rank
+			}
+		}
+	}
+
+	if bestBench > 0 {
+		actionKey := fmt.Sprintf("bench_swap_%d_%d", current, bestBench)
+		if lcu.isActionProcessed(actionKey) {
+			return
+		}
+
+		// 交换失败（例如英雄已被队友换走）时不标记，下次会话更新时重新选择
+		_, err := lcu.request("POST", fmt.Sprintf("/lol-champ-select/v1/session/bench/swap/%d", bestBench), nil)
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to swap with bench champion %d: %v\n", bestBench, err)
+			return
+		}
+		lcu.addProcessedAction(actionKey)
+		fmt.Printf("[INFO] Swapped champion %d for bench champion %d\n", current, bestBench)
+		return
+	}
+
+	// 备选席中没有更好的英雄，当前英雄低于阈值时重随
+	if !config.AramRerollEnabled || !config.ShouldAramReroll(current) {
+		return
+	}
+
+	actionKey := fmt.Sprintf("reroll_%d", current)
+	if lcu.isActionProcessed(actionKey) {
+		return
+	}
+	lcu.addProcessedAction(actionKey)
+
+	if lcu.getRerollsRemaining() <= 0 {
+		fmt.Println("[INFO] No rerolls remaining")
+		return
+	}
+
+	if _, err := lcu.request("POST", "/lol-champ-select/v1/session/my-selection/reroll", nil); err != nil {
+		fmt.Printf("[ERROR] Failed to reroll champion %d: %v\n", current, err)
+		return
+	}
+	fmt.Printf("[INFO] Rerolled champion %d\n", current)
+}
+
+// getRerollsRemaining 获取剩余重随次数
+func (lcu *LCUConnector) getRerollsRemaining() int {
+	points, err := lcu.request("GET", "/lol-summoner/v1/current-summoner/rerollPoints", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get reroll points: %v\n", err)
+		return 0
+	}
+	if rolls, ok := points["numberOfRolls"].(float64); ok {
+		return int(rolls)
+	}
+	return 0
+}
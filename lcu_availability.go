@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ownedChampion /lol-champions/v1/owned-champions-minimal 返回的英雄
+type ownedChampion struct {
+	ID         int  `json:"id"`
+	Active     bool `json:"active"`
+	FreeToPlay bool `json:"freeToPlay"`
+	Ownership  struct {
+		Owned  bool `json:"owned"`
+		Rental struct {
+			Rented bool `json:"rented"`
+		} `json:"rental"`
+	} `json:"ownership"`
+}
+
+// PickUnavailable 没有可选英雄时发送给前端的说明
+type PickUnavailable struct {
+	Position   string   `json:"position"`
+	Candidates []int    `json:"candidates"`
+	Reasons    []string `json:"reasons"`
+}
+
+// loadChampionAvailability 英雄选择开始时获取拥有的英雄和被禁用的英雄
+func (lcu *LCUConnector) loadChampionAvailability() {
+	var owned []ownedChampion
+	ownedErr := lcu.requestJSON("GET", "/lol-champions/v1/owned-champions-minimal", nil, &owned)
+	if ownedErr != nil {
+		fmt.Printf("[ERROR] Failed to get owned champions: %v\n", ownedErr)
+	}
+
+	var disabled []int
+	disabledErr := lcu.requestJSON("GET", "/lol-champ-select/v1/disabled-champion-ids", nil, &disabled)
+	if disabledErr != nil {
+		fmt.Printf("[ERROR] Failed to get disabled champions: %v\n", disabledErr)
+	}
+
+	lcu.availabilityLock.Lock()
+	defer lcu.availabilityLock.Unlock()
+
+	// 获取失败时不做过滤，交给客户端判断
+	lcu.ownedChampions = nil
+	if ownedErr == nil {
+		lcu.ownedChampions = make(map[int]bool, len(owned))
+		for _, champ := range owned {
+			if champ.Active && (champ.Ownership.Owned || champ.Ownership.Rental.Rented || champ.FreeToPlay) {
+				lcu.ownedChampions[champ.ID] = true
+			}
+		}
+	}
+
+	lcu.disabledChampions = make(map[int]bool, len(disabled))
+	for _, id := range disabled {
+		lcu.disabledChampions[id] = true
+	}
+
+	fmt.Printf("[INFO] Loaded champion availability: %d playable, %d disabled\n", len(lcu.ownedChampions), len(lcu.disabledChampions))
+}
+
+// clearChampionAvailability 清理英雄可用性数据
+func (lcu *LCUConnector) clearChampionAvailability() {
+	lcu.availabilityLock.Lock()
+	defer lcu.availabilityLock.Unlock()
+	lcu.ownedChampions = nil
+	lcu.disabledChampions = nil
+}
+
+// championUnavailableReason 返回英雄不可用的原因，可用时返回空字符串
+func (lcu *LCUConnector) championUnavailableReason(championID int) string {
+	lcu.availabilityLock.RLock()
+	defer lcu.availabilityLock.RUnlock()
+
+	if lcu.disabledChampions[championID] {
+		return fmt.Sprintf("champion %d is disabled", championID)
+	}
+	if lcu.ownedChampions != nil && !lcu.ownedChampions[championID] {
+		return fmt.Sprintf("champion %d is not owned", championID)
+	}
+	return ""
+}
+
+// filterAvailableCandidates 过滤掉未拥有或被禁用的英雄，同时返回被过滤的原因
+func (lcu *LCUConnector) filterAvailableCandidates(candidates []int) ([]int, []string) {
+	var available []int
+	var reasons []string
+	for _, championID := range candidates {
+		if reason := lcu.championUnavailableReason(championID); reason != "" {
+			reasons = append(reasons, reason)
+			continue
+		}
+		available = append(available, championID)
+	}
+	return available, reasons
+}
+
+// reportPickUnavailable 通知前端没有可选的英雄
+func (lcu *LCUConnector) reportPickUnavailable(position string, candidates []int, reasons []string) {
+	issue := fmt.Sprintf("No configured champion is pickable: %s", strings.Join(reasons, "; "))
+
+	lcu.statusLock.Lock()
+	changed := lcu.status.PickIssue != issue
+	lcu.status.PickIssue = issue
+	lcu.statusLock.Unlock()
+
+	if !changed {
+		return
+	}
+
+	fmt.Printf("[INFO] %s\n", issue)
+	lcu.app.emitEvent("pick-unavailable", PickUnavailable{
+		Position:   position,
+		Candidates: candidates,
+		Reasons:    reasons,
+	})
+}
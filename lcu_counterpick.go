@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// handleCounterPick 根据已公开的敌方英雄计算克制建议，预选模式下自动预选最佳克制英雄
+func (lcu *LCUConnector) handleCounterPick(data map[string]interface{}, localCellID int) {
+	position := lcu.getPlayerAssignedPosition(data, localCellID)
+	candidates := lcu.getPickCandidates(position)
+	if len(candidates) == 0 {
+		return
+	}
+
+	suggestions := lcu.rankCounterPicks(data, localCellID, position, candidates)
+	if !lcu.setCounterPicks(suggestions) || len(suggestions) == 0 {
+		return
+	}
+
+	best := suggestions[0]
+	fmt.Printf("[INFO] Counter pick suggestion: %d (%s)\n", best.ChampionID, best.Reason)
+	lcu.app.emitEvent("counter-pick-suggestions", suggestions)
+
+	if lcu.app.config.CounterPickMode != CounterPickPreselect {
+		return
+	}
+
+	action := lcu.getPickActionForPreselect(data, localCellID)
+	if action == nil {
+		return
+	}
+	actionID := lcu.getActionID(action)
+	if actionID == -1 {
+		return
+	}
+
+	actionKey := fmt.Sprintf("%d_counter_preselect_%d", actionID, best.ChampionID)
+	if lcu.isActionProcessed(actionKey) || lcu.getCurrentPickIntent(data, localCellID) == best.ChampionID {
+		return
+	}
+	lcu.addProcessedAction(actionKey)
+
+	if lcu.patchAction(actionID, best.ChampionID, false) {
+		fmt.Printf("[INFO] Preselected counter pick %d\n", best.ChampionID)
+	} else {
+		fmt.Printf("[ERROR] Failed to preselect counter pick %d\n", best.ChampionID)
+	}
+}
+
+// bestCounterPick 获取最佳克制英雄，没有已公开的对手时返回-1
+func (lcu *LCUConnector) bestCounterPick(data map[string]interface{}, localCellID int, position string, candidates []int) int {
+	suggestions := lcu.rankCounterPicks(data, localCellID, position, candidates)
+	if len(suggestions) == 0 {
+		return -1
+	}
+	return suggestions[0].ChampionID
+}
+
+// rankCounterPicks 对可选的候选英雄按克制关系排序
+// 有对位对手时只考虑对位对手，否则考虑所有已公开的敌方英雄
+func (lcu *LCUConnector) rankCounterPicks(data map[string]interface{}, localCellID int, position string, candidates []int) []CounterPickSuggestion {
+	matchups := lcu.app.getMatchups()
+	if matchups == nil || matchups.Len() == 0 {
+		return nil
+	}
+
+	laneOpponent, opponents := lcu.getRevealedEnemies(data, position)
+	if laneOpponent > 0 {
+		opponents = []int{laneOpponent}
+	}
+	if len(opponents) == 0 {
+		return nil
+	}
+
+	available := lcu.filterPickableCandidates(data, localCellID, candidates)
+	if len(available) == 0 {
+		return nil
+	}
+
+	return matchups.Rank(available, opponents, position)
+}
+
+// getRevealedEnemies 获取已公开的敌方英雄，以及与指定位置对位的英雄
+func (lcu *LCUConnector) getRevealedEnemies(data map[string]interface{}, position string) (int, []int) {
+	theirTeam, ok := data["theirTeam"].([]interface{})
+	if !ok {
+		return -1, nil
+	}
+
+	laneOpponent := -1
+	var enemies []int
+	for _, player := range theirTeam {
+		playerMap, ok := player.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		championID, _ := playerMap["championId"].(float64)
+		if championID <= 0 {
+			continue
+		}
+		enemies = append(enemies, int(championID))
+
+		assigned, _ := playerMap["assignedPosition"].(string)
+		if position != "" && strings.EqualFold(assigned, position) {
+			laneOpponent = int(championID)
+		}
+	}
+
+	return laneOpponent, enemies
+}
+
+// filterPickableCandidates 过滤掉已被Ban、被队友或敌方选走的英雄
+func (lcu *LCUConnector) filterPickableCandidates(data map[string]interface{}, localCellID int, candidates []int) []int {
+	declared := lcu.getAllyDeclaredChampions(data)
+	banned := lcu.getBannedChampions(data)
+	_, enemies := lcu.getRevealedEnemies(data, "")
+	taken := make(map[int]bool)
+	for _, championID := range enemies {
+		taken[championID] = true
+	}
+
+	var available []int
+	for _, championID := range candidates {
+		if cellID, ok := declared[championID]; ok && cellID != localCellID {
+			continue
+		}
+		if banned[championID] || taken[championID] {
+			continue
+		}
+		if lcu.championUnavailableReason(championID) != "" {
+			continue
+		}
+		available = append(available, championID)
+	}
+	return available
+}
+
+// setCounterPicks 更新克制建议，返回建议是否发生变化
+func (lcu *LCUConnector) setCounterPicks(suggestions []CounterPickSuggestion) bool {
+	lcu.counterPickLock.Lock()
+	defer lcu.counterPickLock.Unlock()
+
+	if sameCounterPicks(lcu.counterPicks, suggestions) {
+		return false
+	}
+	lcu.counterPicks = suggestions
+	return true
+}
+
+// GetCounterPicks 获取当前的克制建议
+func (lcu *LCUConnector) GetCounterPicks() []CounterPickSuggestion {
+	lcu.counterPickLock.RLock()
+	defer lcu.counterPickLock.RUnlock()
+
+	suggestions := make([]CounterPickSuggestion, len(lcu.counterPicks))
+	copy(suggestions, lcu.counterPicks)
+	return suggestions
+}
+
+// sameCounterPicks 比较两组克制建议是否相同
+func sameCounterPicks(a, b []CounterPickSuggestion) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].ChampionID != b[i].ChampionID || a[i].Score != b[i].Score || a[i].Reason != b[i].Reason {
+			return false
+		}
+	}
+	return true
+}
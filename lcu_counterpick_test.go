@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestCounterPickUsesMasteryCandidates(t *testing.T) {
+	matchups := NewMatchupTable()
+	matchups.Add(Matchup{ChampionID: 10, OpponentID: 50, Score: 2})
+
+	mastery := NewMasteryService()
+	mastery.masteries = map[int]ChampionMastery{10: {ChampionID: 10, ChampionPoints: 1000}}
+
+	// 没有配置英雄池，只有开启按成就排序后才会有候选英雄
+	app := &App{config: &Config{PickOrderByMastery: true}, matchups: matchups, mastery: mastery}
+	lcu := NewLCUConnector(app, WithTransport(&replayTransport{}), WithEventStream(newFakeEventStream()))
+
+	data := map[string]interface{}{
+		"myTeam": []interface{}{
+			map[string]interface{}{"cellId": float64(0), "assignedPosition": "top"},
+		},
+		"theirTeam": []interface{}{
+			map[string]interface{}{"cellId": float64(5), "championId": float64(50), "assignedPosition": "top"},
+		},
+	}
+	lcu.handleCounterPick(data, 0)
+
+	suggestions := lcu.GetCounterPicks()
+	if len(suggestions) != 1 || suggestions[0].ChampionID != 10 {
+		t.Errorf("GetCounterPicks() = %+v, want champion 10 from mastery", suggestions)
+	}
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/ImOlli/go-lcu/lcu"
+)
+
+const (
+	// lcuPortEnv 手动指定LCU端口的环境变量
+	lcuPortEnv = "AUTOBP_LCU_PORT"
+	// lcuTokenEnv 手动指定LCU令牌的环境变量
+	lcuTokenEnv = "AUTOBP_LCU_TOKEN"
+	// leaguePathEnv 指定英雄联盟安装目录的环境变量
+	leaguePathEnv = "AUTOBP_LEAGUE_PATH"
+	// lockfileName 客户端运行时在安装目录下生成的凭据文件
+	lockfileName = "lockfile"
+)
+
+// 凭据来源
+const (
+	CredentialSourceProcess  = "process"
+	CredentialSourceLockfile = "lockfile"
+	CredentialSourceExplicit = "explicit"
+	CredentialSourceStatic   = "static"
+)
+
+// CredentialProvider LCU凭据提供者
+type CredentialProvider interface {
+	// Name 提供者名称，会显示在连接状态中
+	Name() string
+	// Find 查找凭据，找不到时返回错误
+	Find() (*LCUCredentials, error)
+}
+
+// WithCredentialProviders 使用指定的凭据提供者链
+func WithCredentialProviders(providers ...CredentialProvider) LCUOption {
+	return func(lcu *LCUConnector) {
+		lcu.providers = providers
+	}
+}
+
+// WithCredentials 使用固定的凭据，不再自动查找
+func WithCredentials(creds *LCUCredentials) LCUOption {
+	return WithCredentialProviders(&staticCredentialProvider{creds: creds})
+}
+
+// defaultCredentialProviders 默认的凭据提供者链: 进程命令行 > lockfile > 手动指定
+// 手动指定了端口和令牌时优先使用手动指定的凭据
+func defaultCredentialProviders(config *Config) []CredentialProvider {
+	var installPath string
+	if config != nil {
+		installPath = config.LeagueInstallPath
+	}
+	if path := os.Getenv(leaguePathEnv); path != "" {
+		installPath = path
+	}
+
+	explicit := newExplicitCredentialProvider(config)
+	providers := []CredentialProvider{
+		&processCredentialProvider{},
+		&lockfileCredentialProvider{installPath: installPath, candidates: leagueInstallCandidates},
+	}
+	if explicit.configured() {
+		return append([]CredentialProvider{explicit}, providers...)
+	}
+	return append(providers, explicit)
+}
+
+// findCredentials 依次尝试各个提供者，返回第一个找到的凭据
+func findCredentials(providers []CredentialProvider) (*LCUCredentials, error) {
+	var errs []string
+	for _, provider := range providers {
+		creds, err := provider.Find()
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", provider.Name(), err))
+			continue
+		}
+		creds.Source = provider.Name()
+		return creds, nil
+	}
+	return nil, fmt.Errorf("no credential provider succeeded (%s)", strings.Join(errs, "; "))
+}
+
+// processCredentialProvider 从LeagueClientUx进程的命令行参数读取凭据
+type processCredentialProvider struct{}
+
+// Name 提供者名称
+func (p *processCredentialProvider) Name() string {
+	return CredentialSourceProcess
+}
+
+// Find 使用go-lcu库从进程命令行获取LCU连接信息
+func (p *processCredentialProvider) Find() (*LCUCredentials, error) {
+	info, err := lcu.FindLCUConnectInfo()
+	if err != nil {
+		if lcu.IsProcessNotFoundError(err) {
+			return nil, fmt.Errorf("LeagueClientUx.exe process not found - League client may not be running")
+		}
+		return nil, err
+	}
+
+	port, err := strconv.Atoi(info.Port)
+	if err != nil {
+		return nil, fmt.Errorf("invalid port number: %s", info.Port)
+	}
+
+	return &LCUCredentials{
+		Port:     port,
+		Token:    info.AuthToken,
+		Protocol: "https",
+	}, nil
+}
+
+// lockfileCredentialProvider 从安装目录下的lockfile读取凭据
+type lockfileCredentialProvider struct {
+	installPath string          // 为空时自动检测
+	candidates  func() []string // 自动检测时依次尝试的目录
+}
+
+// Name 提供者名称
+func (p *lockfileCredentialProvider) Name() string {
+	return CredentialSourceLockfile
+}
+
+// Find 读取第一个存在的lockfile
+func (p *lockfileCredentialProvider) Find() (*LCUCredentials, error) {
+	var dirs []string
+	if p.candidates != nil {
+		dirs = p.candidates()
+	}
+	if p.installPath != "" {
+		dirs = []string{p.installPath}
+	}
+
+	// 损坏的lockfile（例如客户端正在写入）不影响后面的目录
+	var errs []string
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, lockfileName))
+		if err != nil {
+			continue
+		}
+		creds, err := parseLockfile(string(data))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("invalid lockfile in %s: %v", dir, err))
+			continue
+		}
+		return creds, nil
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("no valid lockfile found (%s)", strings.Join(errs, "; "))
+	}
+	return nil, fmt.Errorf("lockfile not found")
+}
+
+// parseLockfile 解析lockfile，格式为 "名称:进程ID:端口:令牌:协议"
+func parseLockfile(content string) (*LCUCredentials, error) {
+	parts := strings.Split(strings.TrimSpace(content), ":")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("unexpected format")
+	}
+
+	port, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid port number: %s", parts[2])
+	}
+
+	return &LCUCredentials{
+		Port:     port,
+		Token:    parts[3],
+		Protocol: parts[4],
+	}, nil
+}
+
+// leagueInstallCandidates 返回常见的英雄联盟安装目录
+func leagueInstallCandidates() []string {
+	switch runtime.GOOS {
+	case "windows":
+		var dirs []string
+		for _, drive := range []string{"C:", "D:", "E:"} {
+			dirs = append(dirs, filepath.Join(drive+`\`, "Riot Games", "League of Legends"))
+		}
+		return dirs
+	case "darwin":
+		return []string{"/Applications/League of Legends.app/Contents/LoL"}
+	default:
+		// Linux下通过Wine运行
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		prefixes := []string{
+			filepath.Join(home, ".wine"),
+			filepath.Join(home, "Games", "league-of-legends"),
+		}
+		if prefix := os.Getenv("WINEPREFIX"); prefix != "" {
+			prefixes = append([]string{prefix}, prefixes...)
+		}
+		var dirs []string
+		for _, prefix := range prefixes {
+			dirs = append(dirs, filepath.Join(prefix, "drive_c", "Riot Games", "League of Legends"))
+		}
+		return dirs
+	}
+}
+
+// explicitCredentialProvider 使用配置或环境变量中手动指定的端口和令牌，环境变量优先
+type explicitCredentialProvider struct {
+	port  int
+	token string
+}
+
+// newExplicitCredentialProvider 从配置和环境变量创建提供者
+func newExplicitCredentialProvider(config *Config) *explicitCredentialProvider {
+	p := &explicitCredentialProvider{}
+	if config != nil {
+		p.port = config.LCUPort
+		p.token = config.LCUToken
+	}
+	if port, err := strconv.Atoi(os.Getenv(lcuPortEnv)); err == nil {
+		p.port = port
+	}
+	if token := os.Getenv(lcuTokenEnv); token != "" {
+		p.token = token
+	}
+	return p
+}
+
+// Name 提供者名称
+func (p *explicitCredentialProvider) Name() string {
+	return CredentialSourceExplicit
+}
+
+// configured 是否同时指定了端口和令牌
+func (p *explicitCredentialProvider) configured() bool {
+	return p.port > 0 && p.token != ""
+}
+
+// Find 返回手动指定的凭据
+func (p *explicitCredentialProvider) Find() (*LCUCredentials, error) {
+	if !p.configured() {
+		return nil, fmt.Errorf("port or token not configured")
+	}
+	return &LCUCredentials{
+		Port:     p.port,
+		Token:    p.token,
+		Protocol: "https",
+	}, nil
+}
+
+// staticCredentialProvider 返回固定的凭据
+type staticCredentialProvider struct {
+	creds *LCUCredentials
+}
+
+// Name 提供者名称
+func (p *staticCredentialProvider) Name() string {
+	return CredentialSourceStatic
+}
+
+// Find 返回固定的凭据
+func (p *staticCredentialProvider) Find() (*LCUCredentials, error) {
+	creds := *p.creds
+	return &creds, nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLockfileProviderSkipsMalformedLockfile(t *testing.T) {
+	broken := t.TempDir()
+	valid := t.TempDir()
+	if err := os.WriteFile(filepath.Join(broken, lockfileName), []byte("LeagueClient:1234"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(valid, lockfileName), []byte("LeagueClient:1234:54321:secret:https"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &lockfileCredentialProvider{candidates: func() []string { return []string{broken, valid} }}
+	creds, err := provider.Find()
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+	if creds.Port != 54321 || creds.Token != "secret" || creds.Protocol != "https" {
+		t.Errorf("Find() = %+v, want port 54321 token secret", creds)
+	}
+
+	provider = &lockfileCredentialProvider{candidates: func() []string { return []string{broken} }}
+	if _, err := provider.Find(); err == nil {
+		t.Error("Find() with only a malformed lockfile should fail")
+	}
+}
+
+func TestDefaultCredentialProvidersOrder(t *testing.T) {
+	t.Setenv(lcuPortEnv, "")
+	t.Setenv(lcuTokenEnv, "")
+
+	config := DefaultConfig()
+	providers := defaultCredentialProviders(config)
+	if name := providers[0].Name(); name != CredentialSourceProcess {
+		t.Errorf("first provider without explicit credentials = %s, want %s", name, CredentialSourceProcess)
+	}
+
+	config.LCUPort = 54321
+	config.LCUToken = "secret"
+	providers = defaultCredentialProviders(config)
+	if name := providers[0].Name(); name != CredentialSourceExplicit {
+		t.Fatalf("first provider with explicit credentials = %s, want %s", name, CredentialSourceExplicit)
+	}
+	creds, err := findCredentials(providers)
+	if err != nil {
+		t.Fatalf("findCredentials() error = %v", err)
+	}
+	if creds.Source != CredentialSourceExplicit || creds.Port != 54321 {
+		t.Errorf("findCredentials() = %+v, want explicit port 54321", creds)
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// eogFetchAttempts 进入结算阶段后获取结算数据的最大尝试次数
+	eogFetchAttempts = 3
+	// eogFetchInterval 结算数据尚未生成时的重试间隔
+	eogFetchInterval = 2 * time.Second
+)
+
+// handleEndOfGame 进入结算阶段时获取并保存对局结算数据
+func (lcu *LCUConnector) handleEndOfGame(previousPhase, phase string) {
+	if phase != "EndOfGame" || previousPhase == "EndOfGame" {
+		return
+	}
+	lcu.spawn(lcu.captureEndOfGame)
+}
+
+// captureEndOfGame 获取结算数据，整理后交给App保存
+func (lcu *LCUConnector) captureEndOfGame() {
+	var block eogStatsBlock
+	var err error
+	for attempt := 0; attempt < eogFetchAttempts; attempt++ {
+		if attempt > 0 {
+			lcu.sleep(eogFetchInterval)
+		}
+		if err = lcu.requestJSON("GET", "/lol-end-of-game/v1/eog-stats-block", nil, &block); err == nil && block.GameID != 0 {
+			break
+		}
+	}
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get end of game stats: %v\n", err)
+		return
+	}
+	if block.GameID == 0 {
+		fmt.Println("[WARNING] End of game stats are empty")
+		return
+	}
+
+	summary := newGameSummary(&block)
+	summary.LPChange = lcu.getLPChange(block.GameID)
+
+	fmt.Printf("[INFO] Game %d finished: win=%v, %d/%d/%d\n", summary.GameID, summary.Win, summary.Player.Kills, summary.Player.Deaths, summary.Player.Assists)
+	lcu.app.recordGameSummary(summary)
+}
+
+// getLPChange 获取本局的胜点变化，非排位赛或数据不可用时返回nil
+func (lcu *LCUConnector) getLPChange(gameID int64) *int {
+	var notification struct {
+		GameID            int64 `json:"gameId"`
+		LeaguePointsDelta *int  `json:"leaguePointsDelta"`
+	}
+	if err := lcu.requestJSON("GET", "/lol-ranked/v1/current-lp-change-notification", nil, &notification); err != nil {
+		return nil
+	}
+	// 通知可能属于上一局排位赛，没有对局ID时也无法确认，一律不使用
+	if notification.GameID != gameID {
+		return nil
+	}
+	return notification.LeaguePointsDelta
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGetLPChange(t *testing.T) {
+	tests := []struct {
+		name         string
+		notification string
+		want         *int
+	}{
+		{name: "matching game", notification: `{"gameId":1001,"leaguePointsDelta":21}`, want: intPtr(21)},
+		{name: "previous game", notification: `{"gameId":1000,"leaguePointsDelta":-18}`},
+		{name: "missing game id", notification: `{"leaguePointsDelta":19}`},
+		{name: "no notification"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := map[string]json.RawMessage{}
+			if tt.notification != "" {
+				responses["GET /lol-ranked/v1/current-lp-change-notification"] = json.RawMessage(tt.notification)
+			}
+			lcu := NewLCUConnector(&App{config: DefaultConfig()}, WithTransport(&replayTransport{responses: responses}))
+			lcu.credentials = &LCUCredentials{Token: "test", Protocol: "https"}
+			lcu.setConnected(true)
+
+			got := lcu.getLPChange(1001)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("getLPChange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func intPtr(v int) *int {
+	return &v
+}
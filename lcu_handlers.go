@@ -2,21 +2,100 @@ package main
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
+// ReadyCheck 准备检查事件数据
+type ReadyCheck struct {
+	State          string  `json:"state"`          // InProgress, EveryoneReady, StrangerNotReady, PartyNotReady, Invalid
+	PlayerResponse string  `json:"playerResponse"` // None, Accepted, Declined
+	Timer          float64 `json:"timer"`          // 已经过的秒数
+}
+
+// parseReadyCheck 解析准备检查事件数据
+func parseReadyCheck(eventData interface{}) *ReadyCheck {
+	data, ok := eventData.(map[string]interface{})
+	if !ok || data == nil {
+		return nil
+	}
+
+	readyCheck := &ReadyCheck{}
+	readyCheck.State, _ = data["state"].(string)
+	readyCheck.PlayerResponse, _ = data["playerResponse"].(string)
+	readyCheck.Timer, _ = data["timer"].(float64)
+	return readyCheck
+}
+
 // handleReadyCheck 处理准备检查事件
-func (lcu *LCUConnector) handleReadyCheck(_ interface{}) {
-	if !lcu.app.config.AutoAcceptEnabled {
+func (lcu *LCUConnector) handleReadyCheck(eventData interface{}) {
+	readyCheck := parseReadyCheck(eventData)
+
+	lcu.readyCheckLock.Lock()
+
+	// 准备检查结束（有人拒绝、全部接受或已被删除），下一次准备检查需要重新处理
+	if readyCheck == nil || readyCheck.State != "InProgress" || readyCheck.PlayerResponse != "None" {
+		if lcu.readyCheckResponded {
+			lcu.readyCheckResponded = false
+			lcu.readyCheckSeq++
+		}
+		lcu.readyCheckLock.Unlock()
 		return
 	}
-	
-	if lcu.readyCheckAccepted {
+
+	config := lcu.app.config
+	if lcu.readyCheckResponded || (!config.AutoAcceptEnabled && !config.AwayModeEnabled) {
+		lcu.readyCheckLock.Unlock()
 		return
 	}
-	
-	lcu.readyCheckAccepted = true
-	go lcu.acceptReadyCheck()
+
+	lcu.readyCheckResponded = true
+	seq := lcu.readyCheckSeq
+	// 响应可能同步执行并再次检查准备检查状态，需要先释放锁
+	lcu.readyCheckLock.Unlock()
+
+	if config.AwayModeEnabled {
+		lcu.spawn(lcu.declineReadyCheck)
+		return
+	}
+
+	// 扣除准备检查已经过的时间
+	delay := config.GetAutoAcceptDelay() - time.Duration(readyCheck.Timer*float64(time.Second))
+	if delay < 0 {
+		delay = 0
+	}
+	lcu.spawn(func() { lcu.acceptReadyCheck(seq, delay) })
+}
+
+// resetReadyCheck 重置准备检查状态
+func (lcu *LCUConnector) resetReadyCheck() {
+	lcu.readyCheckLock.Lock()
+	defer lcu.readyCheckLock.Unlock()
+	lcu.readyCheckResponded = false
+	lcu.readyCheckSeq++
+}
+
+// isReadyCheckCurrent 检查准备检查是否仍是发起响应时的那一次
+func (lcu *LCUConnector) isReadyCheckCurrent(seq int) bool {
+	lcu.readyCheckLock.Lock()
+	defer lcu.readyCheckLock.Unlock()
+	return lcu.readyCheckResponded && lcu.readyCheckSeq == seq
+}
+
+// getCurrentQueueID 获取当前游戏流程的队列ID
+func (lcu *LCUConnector) getCurrentQueueID() int {
+	session, err := lcu.request("GET", "/lol-gameflow/v1/session", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get gameflow session: %v\n", err)
+		return -1
+	}
+
+	gameData, _ := session["gameData"].(map[string]interface{})
+	queue, _ := gameData["queue"].(map[string]interface{})
+	if queueID, ok := queue["id"].(float64); ok {
+		return int(queueID)
+	}
+	return -1
 }
 
 // handleGameflowPhase 处理游戏流程阶段变化
@@ -27,27 +106,44 @@ func (lcu *LCUConnector) handleGameflowPhase(eventData interface{}) {
 	}
 	
 	lcu.statusLock.Lock()
+	previousPhase := lcu.status.ClientStatus
 	lcu.status.ClientStatus = phase
+	lcu.status.PickIssue = ""
 	lcu.statusLock.Unlock()
 	
 	fmt.Printf("[INFO] Game phase changed to: %s\n", phase)
 	
+	lcu.handleRequeue(previousPhase, phase)
+	lcu.handleLiveGame(previousPhase, phase)
+	lcu.handleEndOfGame(previousPhase, phase)
+	
 	// 清理状态
 	switch phase {
-	case "Lobby", "Matchmaking", "ReadyCheck":
+	case "Lobby", "Matchmaking":
+		lcu.clearProcessedActions()
+		lcu.lastPreselectChampion = nil
+		lcu.resetReadyCheck()
+		lcu.clearLoggedWarnings()
+		lcu.setCounterPicks(nil)
+	case "ReadyCheck":
+		// 准备检查事件可能先于阶段事件到达，这里不重置准备检查状态
 		lcu.clearProcessedActions()
 		lcu.lastPreselectChampion = nil
-		lcu.readyCheckAccepted = false
 		lcu.clearLoggedWarnings()
 	case "ChampSelect":
 		lcu.clearProcessedActions()
 		lcu.clearLoggedWarnings()
+		lcu.setCounterPicks(nil)
+		lcu.resetScouting()
+		lcu.loadChampionAvailability()
+		lcu.loadChampionMastery()
 		lcu.updateChampSelectDetails()
 	default:
 		lcu.statusLock.Lock()
 		lcu.status.ChampSelect = nil
 		lcu.statusLock.Unlock()
 		lcu.clearProcessedActions()
+		lcu.clearChampionAvailability()
 	}
 }
 
@@ -77,24 +173,58 @@ func (lcu *LCUConnector) handleChampSelect(eventData interface{}) {
 	}
 	
 	// 处理自动Ban
-	if lcu.app.config.AutoBanEnabled && lcu.app.config.AutoBanChampionID != nil && phase == "BAN_PICK" {
+	if lcu.app.config.AutoBanEnabled && phase == "BAN_PICK" {
 		lcu.handleAutoBan(data, localCellID)
 	}
 	
+	// 处理交换请求
+	lcu.handleSwapRequests(data, localCellID)
+	
+	// 侦察队友
+	lcu.handleScouting(data, localCellID)
+	
+	// 极地大乱斗备选席
+	if benchEnabled, _ := data["benchEnabled"].(bool); benchEnabled && lcu.app.config.AramEnabled {
+		lcu.handleAramBench(data, localCellID)
+	}
+	
+	// 计算克制选择建议
+	mode := lcu.app.config.CounterPickMode
+	if mode != "" && mode != CounterPickOff && (phase == "BAN_PICK" || phase == "FINALIZATION") {
+		lcu.handleCounterPick(data, localCellID)
+	}
+	
 	// 处理自动Pick
 	if lcu.app.config.AutoPickEnabled && (phase == "BAN_PICK" || phase == "FINALIZATION") {
 		lcu.handleAutoPick(data, localCellID)
 	}
+	
+	// 英雄锁定后应用符文等配置
+	lcu.handleChampionLocked(data, localCellID)
+	
+	// 最终阶段选择皮肤
+	if phase == "FINALIZATION" {
+		lcu.handleSkinSelection(data, localCellID)
+	}
 }
 
-// acceptReadyCheck 自动接受对局
-func (lcu *LCUConnector) acceptReadyCheck() {
-	// 检查当前游戏状态，只有在ReadyCheck阶段才尝试接受
-	lcu.statusLock.Lock()
-	currentPhase := lcu.status.ClientStatus
-	lcu.statusLock.Unlock()
-	
-	if currentPhase != "ReadyCheck" {
+// acceptReadyCheck 延迟后自动接受对局
+func (lcu *LCUConnector) acceptReadyCheck(seq int, delay time.Duration) {
+	if len(lcu.app.config.AutoAcceptQueueIDs) > 0 {
+		queueID := lcu.getCurrentQueueID()
+		if !lcu.app.config.ShouldAutoAcceptQueue(queueID) {
+			fmt.Printf("[INFO] Queue %d is not configured for auto accept, skipping\n", queueID)
+			return
+		}
+	}
+
+	if delay > 0 {
+		fmt.Printf("[INFO] Accepting ready check in %v\n", delay)
+		lcu.sleep(delay)
+	}
+
+	// 准备检查事件可能先于游戏流程阶段事件到达，因此不检查当前阶段，只要求仍是同一次准备检查
+	if !lcu.isReadyCheckCurrent(seq) {
 		return
 	}
 	
@@ -106,6 +236,16 @@ func (lcu *LCUConnector) acceptReadyCheck() {
 	}
 }
 
+// declineReadyCheck 离开模式下自动拒绝对局
+func (lcu *LCUConnector) declineReadyCheck() {
+	_, err := lcu.request("POST", "/lol-matchmaking/v1/ready-check/decline", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to decline ready check: %v\n", err)
+	} else {
+		fmt.Println("[INFO] Away mode: declined ready check")
+	}
+}
+
 // handlePreselect 处理预选英雄
 func (lcu *LCUConnector) handlePreselect(data map[string]interface{}, localCellID int) {
 	var currentChampion *int
@@ -142,6 +282,15 @@ func (lcu *LCUConnector) handlePreselect(data map[string]interface{}, localCellI
 		return
 	}
 	
+	if reason := lcu.championUnavailableReason(*currentChampion); reason != "" {
+		warningKey := fmt.Sprintf("preselect_unavailable_%d", *currentChampion)
+		if !lcu.isWarningLogged(warningKey) {
+			fmt.Printf("[INFO] Skipping preselect: %s\n", reason)
+			lcu.addLoggedWarning(warningKey)
+		}
+		return
+	}
+	
 	// 检查当前选择的英雄是否已经是目标英雄
 	currentPickIntent := lcu.getCurrentPickIntent(data, localCellID)
 	if currentPickIntent == *currentChampion && lcu.lastPreselectChampion != nil && *lcu.lastPreselectChampion == *currentChampion {
@@ -180,6 +329,14 @@ func (lcu *LCUConnector) handlePreselect(data map[string]interface{}, localCellI
 	}
 }
 
+// BanSubstitution Ban英雄被替换的说明，发送给前端
+type BanSubstitution struct {
+	OriginalChampionID   int    `json:"originalChampionId"`
+	SubstituteChampionID int    `json:"substituteChampionId"`
+	Position             string `json:"position"`
+	Reason               string `json:"reason"`
+}
+
 // handleAutoBan 处理自动Ban
 func (lcu *LCUConnector) handleAutoBan(data map[string]interface{}, localCellID int) {
 	action := lcu.getCurrentAction(data, localCellID, "ban")
@@ -197,13 +354,43 @@ func (lcu *LCUConnector) handleAutoBan(data map[string]interface{}, localCellID
 		return
 	}
 	
-	championID := *lcu.app.config.AutoBanChampionID
+	position := lcu.getPlayerAssignedPosition(data, localCellID)
+	candidates := lcu.app.config.GetBanCandidates(position)
+	if len(candidates) == 0 {
+		warningKey := "no_ban_champion"
+		if !lcu.isWarningLogged(warningKey) {
+			fmt.Println("[INFO] No ban champion configured")
+			lcu.addLoggedWarning(warningKey)
+		}
+		return
+	}
+	
+	championID, skipped := lcu.selectBanChampion(data, candidates)
+	if len(skipped) > 0 {
+		substitution := BanSubstitution{
+			OriginalChampionID:   candidates[0],
+			SubstituteChampionID: championID,
+			Position:             position,
+			Reason:               strings.Join(skipped, "; "),
+		}
+		if championID > 0 {
+			fmt.Printf("[INFO] Ban substituted %d -> %d: %s\n", candidates[0], championID, substitution.Reason)
+		} else {
+			fmt.Printf("[INFO] All ban candidates are unavailable, skipping auto ban: %s\n", substitution.Reason)
+		}
+		lcu.app.emitEvent("ban-substituted", substitution)
+	}
+	if championID <= 0 {
+		lcu.addProcessedAction(actionKey)
+		return
+	}
+	
 	fmt.Printf("[INFO] Auto banning champion %d (action %d)\n", championID, actionID)
 	
 	lcu.addProcessedAction(actionKey)
 	
 	// 延迟0.5秒
-	time.Sleep(500 * time.Millisecond)
+	lcu.sleep(500 * time.Millisecond)
 	
 	success := lcu.patchAction(actionID, championID, true)
 	if success {
@@ -213,6 +400,82 @@ func (lcu *LCUConnector) handleAutoBan(data map[string]interface{}, localCellID
 	}
 }
 
+// selectBanChampion 从候选列表中选出第一个可以Ban的英雄，同时返回跳过的原因
+func (lcu *LCUConnector) selectBanChampion(data map[string]interface{}, candidates []int) (int, []string) {
+	declared := lcu.getAllyDeclaredChampions(data)
+	banned := lcu.getBannedChampions(data)
+	
+	var skipped []string
+	for _, championID := range candidates {
+		if cellID, ok := declared[championID]; ok {
+			skipped = append(skipped, fmt.Sprintf("champion %d declared by ally cell %d", championID, cellID))
+			continue
+		}
+		if banned[championID] {
+			skipped = append(skipped, fmt.Sprintf("champion %d already banned", championID))
+			continue
+		}
+		return championID, skipped
+	}
+	
+	return -1, skipped
+}
+
+// getAllyDeclaredChampions 获取所有队友预选或已选的英雄，返回英雄ID到CellID的映射
+func (lcu *LCUConnector) getAllyDeclaredChampions(data map[string]interface{}) map[int]int {
+	declared := make(map[int]int)
+	myTeam, ok := data["myTeam"].([]interface{})
+	if !ok {
+		return declared
+	}
+	
+	for _, player := range myTeam {
+		playerMap, ok := player.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		cellID, _ := playerMap["cellId"].(float64)
+		if intent, ok := playerMap["championPickIntent"].(float64); ok && intent > 0 {
+			declared[int(intent)] = int(cellID)
+		}
+		if championID, ok := playerMap["championId"].(float64); ok && championID > 0 {
+			declared[int(championID)] = int(cellID)
+		}
+	}
+	
+	return declared
+}
+
+// getBannedChampions 获取已经被Ban的英雄
+func (lcu *LCUConnector) getBannedChampions(data map[string]interface{}) map[int]bool {
+	banned := make(map[int]bool)
+	actions, ok := data["actions"].([]interface{})
+	if !ok {
+		return banned
+	}
+	
+	for _, actionGroup := range actions {
+		group, ok := actionGroup.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, action := range group {
+			actionMap, ok := action.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			aType, _ := actionMap["type"].(string)
+			completed, _ := actionMap["completed"].(bool)
+			championID, _ := actionMap["championId"].(float64)
+			if aType == "ban" && completed && championID > 0 {
+				banned[int(championID)] = true
+			}
+		}
+	}
+	
+	return banned
+}
+
 // handleAutoPick 处理自动Pick
 func (lcu *LCUConnector) handleAutoPick(data map[string]interface{}, localCellID int) {
 	action := lcu.getCurrentAction(data, localCellID, "pick")
@@ -230,39 +493,77 @@ func (lcu *LCUConnector) handleAutoPick(data map[string]interface{}, localCellID
 		return
 	}
 	
-	var championID *int
-	
 	// 获取玩家分配的位置
 	position := lcu.getPlayerAssignedPosition(data, localCellID)
 	
-	if position != "" {
-		// 有分配位置，按位置选择英雄
-		championID = lcu.app.config.GetChampionIDForPosition(position)
-		if championID == nil {
+	candidates := lcu.getPickCandidates(position)
+	if len(candidates) == 0 {
+		if position != "" {
 			warningKey := fmt.Sprintf("no_champion_for_position_%s_auto_pick", position)
 			if !lcu.isWarningLogged(warningKey) {
 				fmt.Printf("[INFO] No champion configured for position %s, skipping auto pick\n", position)
 				lcu.addLoggedWarning(warningKey)
 			}
-			return
-		}
-	} else {
-		// 没有分配位置，使用默认秒选英雄
-		if lcu.app.config.AutoPickChampionID != nil {
-			championID = lcu.app.config.AutoPickChampionID
 		} else {
 			warningKey := "no_default_auto_pick_champion"
 			if !lcu.isWarningLogged(warningKey) {
 				fmt.Println("[INFO] No position assigned and no default champion configured")
 				lcu.addLoggedWarning(warningKey)
 			}
-			return
 		}
+		return
 	}
 	
-	if championID == nil {
+	// 排除未拥有或被禁用的英雄
+	available, reasons := lcu.filterAvailableCandidates(candidates)
+	if len(available) == 0 {
+		lcu.reportPickUnavailable(position, candidates, reasons)
 		return
 	}
+	if len(reasons) > 0 {
+		fmt.Printf("[INFO] Skipping unavailable pick candidates: %s\n", strings.Join(reasons, "; "))
+	}
+	
+	// 排除已被Ban、被队友或敌方选走的英雄，依次使用后面的候选英雄
+	candidates = lcu.filterPickableCandidates(data, localCellID, available)
+	if len(candidates) == 0 {
+		warningKey := fmt.Sprintf("%d_pick_candidates_taken", actionID)
+		if !lcu.isWarningLogged(warningKey) {
+			fmt.Println("[INFO] All pick candidates are banned or taken, skipping auto pick")
+			lcu.addLoggedWarning(warningKey)
+		}
+		return
+	}
+	
+	// 按阵容规则筛选和排序
+	if len(lcu.app.config.CompositionRules) > 0 {
+		teamChampions := getAllyLockedChampions(data, localCellID)
+		ordered, results := ApplyComposition(lcu.app.config.CompositionRules, candidates, teamChampions, lcu.app.championManager.GetChampionTags)
+		for _, result := range results {
+			if len(result.Reasons) > 0 {
+				fmt.Printf("[INFO] Composition rules for champion %d: score %d, forbidden %v (%s)\n", result.ChampionID, result.Score, result.Forbidden, strings.Join(result.Reasons, "; "))
+			}
+		}
+		if len(ordered) == 0 {
+			warningKey := fmt.Sprintf("%d_composition_forbidden", actionID)
+			if !lcu.isWarningLogged(warningKey) {
+				fmt.Println("[INFO] All pick candidates are forbidden by composition rules, skipping auto pick")
+				lcu.addLoggedWarning(warningKey)
+			}
+			return
+		}
+		candidates = ordered
+	}
+	
+	championID := &candidates[0]
+	
+	// 按克制关系选择
+	if lcu.app.config.CounterPickMode == CounterPickLock {
+		if best := lcu.bestCounterPick(data, localCellID, position, candidates); best > 0 && best != *championID {
+			fmt.Printf("[INFO] Counter pick: choosing %d instead of %d\n", best, *championID)
+			championID = &best
+		}
+	}
 	
 	if position != "" {
 		fmt.Printf("[INFO] Auto picking position-based champion %d for %s (action %d)\n", *championID, position, actionID)
@@ -270,13 +571,13 @@ func (lcu *LCUConnector) handleAutoPick(data map[string]interface{}, localCellID
 		fmt.Printf("[INFO] Auto picking default champion %d (action %d)\n", *championID, actionID)
 	}
 	
-	lcu.addProcessedAction(actionKey)
-	
 	// 延迟0.5秒
-	time.Sleep(500 * time.Millisecond)
+	lcu.sleep(500 * time.Millisecond)
 	
+	// 失败时不标记，下次会话更新时重新选择
 	success := lcu.patchAction(actionID, *championID, true)
 	if success {
+		lcu.addProcessedAction(actionKey)
 		fmt.Printf("[INFO] Successfully picked and locked champion %d\n", *championID)
 	} else {
 		fmt.Printf("[ERROR] Failed to pick champion %d\n", *championID)
@@ -408,6 +709,9 @@ func (lcu *LCUConnector) patchAction(actionID int, championID int, completed boo
 	}
 	
 	_, err := lcu.request("PATCH", path, payload)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to patch action %d with champion %d: %v\n", actionID, championID, err)
+	}
 	return err == nil
 }
 
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// failingPatchTransport 前failures次PATCH请求返回错误，其余请求交给replayTransport
+type failingPatchTransport struct {
+	*replayTransport
+	failures int
+}
+
+// Do 实现RESTTransport
+func (t *failingPatchTransport) Do(creds *LCUCredentials, method, path string, body []byte) (int, []byte, error) {
+	if method == "PATCH" && t.failures > 0 {
+		t.failures--
+		t.replayTransport.Do(creds, method, path, body)
+		return http.StatusInternalServerError, []byte(`{"message":"failed"}`), nil
+	}
+	return t.replayTransport.Do(creds, method, path, body)
+}
+
+func TestAutoPickRetriesAfterFailedLock(t *testing.T) {
+	config := DefaultConfig()
+	pickID := 157
+	config.AutoPickEnabled = true
+	config.PositionChampions = map[string]*int{"MIDDLE": &pickID}
+
+	transport := &failingPatchTransport{replayTransport: &replayTransport{}, failures: 1}
+	lcu := NewLCUConnector(&App{config: config, championManager: NewChampionManager()}, WithTransport(transport))
+	lcu.credentials = &LCUCredentials{Token: "test", Protocol: "https"}
+	lcu.setConnected(true)
+	lcu.spawn = func(f func()) { f() }
+	lcu.sleep = func(time.Duration) {}
+
+	session := map[string]interface{}{
+		"localPlayerCellId": float64(2),
+		"timer":             map[string]interface{}{"phase": "BAN_PICK"},
+		"myTeam": []interface{}{
+			map[string]interface{}{"cellId": float64(2), "assignedPosition": "middle"},
+		},
+		"actions": []interface{}{
+			[]interface{}{
+				map[string]interface{}{"id": float64(9), "actorCellId": float64(2), "type": "pick", "completed": false, "isInProgress": true, "championId": float64(0)},
+			},
+		},
+	}
+
+	// 第一次锁定失败，下一次会话更新时应重试，成功后不再重复
+	lcu.handleChampSelect(session)
+	lcu.handleChampSelect(session)
+	lcu.handleChampSelect(session)
+
+	result := &ReplayResult{Requests: transport.requests}
+	want := []ReplayAction{
+		{ActionID: 9, ChampionID: 157, Completed: true},
+		{ActionID: 9, ChampionID: 157, Completed: true},
+	}
+	if got := result.Actions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+)
+
+// managedItemSetPrefix AutoBP管理的出装方案UID前缀
+const managedItemSetPrefix = "autobp-"
+
+// applyItemSet 为锁定的英雄安装出装方案，替换之前由AutoBP为该英雄安装的方案
+func (lcu *LCUConnector) applyItemSet(championID int, position string) {
+	if lcu.app.itemSetLibrary == nil {
+		return
+	}
+	template, ok := lcu.app.itemSetLibrary.Get(championID, position)
+	if !ok {
+		fmt.Printf("[INFO] No item set configured for champion %d\n", championID)
+		return
+	}
+	if err := template.Validate(); err != nil {
+		fmt.Printf("[ERROR] Invalid item set for champion %d: %v\n", championID, err)
+		return
+	}
+
+	version := lcu.app.championManager.GetVersion()
+	if template.IsOutdated(version) {
+		fmt.Printf("[WARNING] Item set for champion %d was saved on patch %s, current patch is %s\n", championID, template.Patch, version)
+	}
+
+	summoner, err := lcu.request("GET", "/lol-summoner/v1/current-summoner", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get current summoner: %v\n", err)
+		return
+	}
+	summonerID, ok := summoner["summonerId"].(float64)
+	if !ok {
+		fmt.Println("[ERROR] Failed to get summoner id for item sets")
+		return
+	}
+
+	path := fmt.Sprintf("/lol-item-sets/v1/item-sets/%d/sets", int64(summonerID))
+	sets, err := lcu.request("GET", path, nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get item sets: %v\n", err)
+		return
+	}
+
+	uid := fmt.Sprintf("%s%d", managedItemSetPrefix, championID)
+	title := template.Title
+	if title == "" {
+		title = fmt.Sprintf("AutoBP %d", championID)
+	}
+	if template.Patch != "" {
+		title = fmt.Sprintf("%s (%s)", title, majorMinor(template.Patch))
+	}
+
+	// 保留其他出装方案，只替换AutoBP为该英雄安装的方案
+	itemSets := []interface{}{}
+	if existing, ok := sets["itemSets"].([]interface{}); ok {
+		for _, set := range existing {
+			if setMap, ok := set.(map[string]interface{}); ok && setMap["uid"] == uid {
+				continue
+			}
+			itemSets = append(itemSets, set)
+		}
+	}
+	itemSets = append(itemSets, map[string]interface{}{
+		"uid":                 uid,
+		"title":               title,
+		"type":                "custom",
+		"map":                 "any",
+		"mode":                "any",
+		"startedFrom":         "blank",
+		"sortrank":            0,
+		"associatedChampions": []int{championID},
+		"associatedMaps":      []int{},
+		"blocks":              template.Blocks,
+	})
+	sets["itemSets"] = itemSets
+
+	if _, err := lcu.request("PUT", path, sets); err != nil {
+		fmt.Printf("[ERROR] Failed to install item set: %v\n", err)
+		return
+	}
+
+	fmt.Printf("[INFO] Installed item set %q for champion %d\n", title, championID)
+}
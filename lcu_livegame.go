@@ -0,0 +1,32 @@
+package main
+
+import "fmt"
+
+// handleLiveGame 进入对局时开始轮询游戏内数据，离开对局时停止
+// 游戏客户端崩溃后的Reconnect阶段仍属于同一局，不停止轮询
+func (lcu *LCUConnector) handleLiveGame(previousPhase, phase string) {
+	switch phase {
+	case "InProgress":
+		if previousPhase != "InProgress" && previousPhase != "Reconnect" {
+			lcu.app.startLiveGame(lcu.getCurrentGameID())
+		}
+	case "Reconnect":
+	default:
+		lcu.app.stopLiveGame()
+	}
+}
+
+// getCurrentGameID 获取当前游戏流程的对局ID
+func (lcu *LCUConnector) getCurrentGameID() int64 {
+	session, err := lcu.request("GET", "/lol-gameflow/v1/session", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get gameflow session: %v\n", err)
+		return 0
+	}
+
+	gameData, _ := session["gameData"].(map[string]interface{})
+	if gameID, ok := gameData["gameId"].(float64); ok {
+		return int64(gameID)
+	}
+	return 0
+}
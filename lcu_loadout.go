@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+)
+
+// handleChampionLocked 检测本地玩家锁定英雄，锁定后应用符文等配置
+func (lcu *LCUConnector) handleChampionLocked(data map[string]interface{}, localCellID int) {
+	championID := lcu.getLockedChampion(data, localCellID)
+	if championID <= 0 {
+		return
+	}
+
+	actionKey := fmt.Sprintf("locked_%d", championID)
+	if lcu.isActionProcessed(actionKey) {
+		return
+	}
+	lcu.addProcessedAction(actionKey)
+
+	position := lcu.getPlayerAssignedPosition(data, localCellID)
+	fmt.Printf("[INFO] Champion %d locked\n", championID)
+
+	lcu.spawn(func() { lcu.applyLoadout(championID, position) })
+}
+
+// applyLoadout 为锁定的英雄应用符文、召唤师技能、出装方案等配置
+func (lcu *LCUConnector) applyLoadout(championID int, position string) {
+	if lcu.app.config.RuneImportEnabled {
+		lcu.applyRunePage(championID, position)
+	}
+	if lcu.app.config.SpellsEnabled {
+		lcu.applySummonerSpells(championID, position)
+	}
+	if lcu.app.config.ItemSetsEnabled {
+		lcu.applyItemSet(championID, position)
+	}
+}
+
+// getLockedChampion 获取本地玩家已锁定的英雄，没有Pick操作的模式使用myTeam中的英雄
+func (lcu *LCUConnector) getLockedChampion(data map[string]interface{}, localCellID int) int {
+	hasPickAction := false
+	if actions, ok := data["actions"].([]interface{}); ok {
+		for _, actionGroup := range actions {
+			group, ok := actionGroup.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, action := range group {
+				actionMap, ok := action.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				actorCellID, _ := actionMap["actorCellId"].(float64)
+				aType, _ := actionMap["type"].(string)
+				if int(actorCellID) != localCellID || aType != "pick" {
+					continue
+				}
+				hasPickAction = true
+				completed, _ := actionMap["completed"].(bool)
+				championID, _ := actionMap["championId"].(float64)
+				if completed && championID > 0 {
+					return int(championID)
+				}
+			}
+		}
+	}
+
+	if hasPickAction {
+		return -1
+	}
+
+	myTeam, _ := data["myTeam"].([]interface{})
+	for _, player := range myTeam {
+		if playerMap, ok := player.(map[string]interface{}); ok {
+			if cellID, ok := playerMap["cellId"].(float64); ok && int(cellID) == localCellID {
+				if championID, ok := playerMap["championId"].(float64); ok && championID > 0 {
+					return int(championID)
+				}
+			}
+		}
+	}
+
+	return -1
+}
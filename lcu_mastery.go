@@ -0,0 +1,31 @@
+package main
+
+import "fmt"
+
+// loadChampionMastery 刷新当前召唤师的英雄成就
+func (lcu *LCUConnector) loadChampionMastery() {
+	if lcu.app.mastery == nil {
+		return
+	}
+	if err := lcu.app.mastery.Load(lcu); err != nil {
+		fmt.Printf("[ERROR] Failed to load champion mastery: %v\n", err)
+	}
+}
+
+// getPickCandidates 获取Pick候选英雄，开启按成就排序时按成就点数重新排列，开启按胜率排序时再按近期胜率排列
+// 没有配置任何候选英雄且开启按成就排序时使用所有有成就点数的英雄，即选择成就最高的可用英雄
+func (lcu *LCUConnector) getPickCandidates(position string) []int {
+	config := lcu.app.config
+	candidates := config.GetPickCandidates(position)
+	if config.PickOrderByMastery && lcu.app.mastery != nil {
+		if len(candidates) == 0 {
+			candidates = lcu.app.mastery.Ranked()
+		} else {
+			candidates = lcu.app.mastery.SortByMastery(candidates)
+		}
+	}
+	if config.PickOrderByWinRate && len(candidates) > 1 {
+		candidates = lcu.app.sortByWinRate(position, candidates)
+	}
+	return candidates
+}
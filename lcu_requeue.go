@@ -0,0 +1,149 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// requeueDelay 回到房间后等待多久再重新排队
+	requeueDelay = 3 * time.Second
+	// requeuePenaltyPadding 排队惩罚结束后额外等待的时间
+	requeuePenaltyPadding = time.Second
+)
+
+// handleRequeue 根据游戏流程阶段变化决定是否自动重新排队
+func (lcu *LCUConnector) handleRequeue(previousPhase, phase string) {
+	switch phase {
+	case "Lobby":
+		// 有人秒退（ChampSelect -> Lobby）或准备检查未通过（ReadyCheck -> Lobby）
+		if previousPhase != "ChampSelect" && previousPhase != "ReadyCheck" {
+			return
+		}
+		// 离开模式下是自己拒绝的对局，不需要重新排队
+		if !lcu.app.config.AutoRequeueEnabled || lcu.app.config.AwayModeEnabled {
+			return
+		}
+		fmt.Printf("[INFO] Returned to lobby from %s, scheduling requeue\n", previousPhase)
+		lcu.scheduleRequeue(requeueDelay)
+	case "InProgress":
+		// 对局已开始，重置重新排队次数
+		lcu.cancelRequeue()
+		lcu.requeueLock.Lock()
+		lcu.requeueAttempts = 0
+		lcu.requeueLock.Unlock()
+	case "Matchmaking", "ReadyCheck", "ChampSelect", "None":
+		// 已经在队列中或离开了房间，取消等待中的重新排队
+		lcu.cancelRequeue()
+	}
+}
+
+// scheduleRequeue 在指定时间后重新开始匹配，之前等待中的重新排队会被取代
+func (lcu *LCUConnector) scheduleRequeue(delay time.Duration) {
+	lcu.requeueLock.Lock()
+	if lcu.requeueCancel != nil {
+		close(lcu.requeueCancel)
+	}
+	cancel := make(chan struct{})
+	lcu.requeueCancel = cancel
+	lcu.requeueLock.Unlock()
+
+	lcu.spawn(func() {
+		if !lcu.wait(delay, cancel) {
+			return
+		}
+		lcu.requeueLock.Lock()
+		current := lcu.requeueCancel == cancel
+		if current {
+			lcu.requeueCancel = nil
+		}
+		lcu.requeueLock.Unlock()
+		if current {
+			lcu.requeue()
+		}
+	})
+}
+
+// cancelRequeue 取消等待中的重新排队，等待的协程会立即退出
+func (lcu *LCUConnector) cancelRequeue() {
+	lcu.requeueLock.Lock()
+	defer lcu.requeueLock.Unlock()
+
+	if lcu.requeueCancel != nil {
+		close(lcu.requeueCancel)
+		lcu.requeueCancel = nil
+	}
+}
+
+// requeue 重新开始匹配，遇到排队惩罚时等待惩罚结束后重试
+func (lcu *LCUConnector) requeue() {
+	if !lcu.IsConnected() || !lcu.app.config.AutoRequeueEnabled {
+		return
+	}
+
+	lcu.statusLock.RLock()
+	currentPhase := lcu.status.ClientStatus
+	lcu.statusLock.RUnlock()
+	if currentPhase != "Lobby" {
+		return
+	}
+
+	// 先检查是否仍有排队惩罚
+	if penalty := lcu.getQueuePenaltyRemaining(); penalty > 0 {
+		fmt.Printf("[INFO] Queue penalty active, requeue in %v\n", penalty)
+		lcu.scheduleRequeue(penalty + requeuePenaltyPadding)
+		return
+	}
+
+	lcu.requeueLock.Lock()
+	maxAttempts := lcu.app.config.AutoRequeueMaxAttempts
+	if maxAttempts > 0 && lcu.requeueAttempts >= maxAttempts {
+		lcu.requeueLock.Unlock()
+		fmt.Printf("[INFO] Auto requeue limit (%d) reached, not requeueing\n", maxAttempts)
+		return
+	}
+	lcu.requeueAttempts++
+	attempt := lcu.requeueAttempts
+	lcu.requeueLock.Unlock()
+
+	_, err := lcu.request("POST", "/lol-lobby/v2/lobby/matchmaking/search", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to requeue (attempt %d): %v\n", attempt, err)
+		return
+	}
+
+	// 开始匹配后惩罚信息才会出现在搜索状态中，此时已在队列中，客户端会在惩罚结束后自动开始匹配
+	if penalty := lcu.getQueuePenaltyRemaining(); penalty > 0 {
+		fmt.Printf("[INFO] Auto requeued (attempt %d), queue penalty remaining %v\n", attempt, penalty)
+		return
+	}
+
+	fmt.Printf("[INFO] Auto requeued (attempt %d)\n", attempt)
+}
+
+// getQueuePenaltyRemaining 获取剩余的排队惩罚时间
+func (lcu *LCUConnector) getQueuePenaltyRemaining() time.Duration {
+	search, err := lcu.request("GET", "/lol-matchmaking/v1/search", nil)
+	if err != nil {
+		// 未在匹配时接口返回404
+		return 0
+	}
+
+	var remaining float64
+	if errors, ok := search["errors"].([]interface{}); ok {
+		for _, e := range errors {
+			if searchError, ok := e.(map[string]interface{}); ok {
+				if penalty, ok := searchError["penaltyTimeRemaining"].(float64); ok && penalty > remaining {
+					remaining = penalty
+				}
+			}
+		}
+	}
+	if lowPriority, ok := search["lowPriorityData"].(map[string]interface{}); ok {
+		if penalty, ok := lowPriority["penaltyTimeRemaining"].(float64); ok && penalty > remaining {
+			remaining = penalty
+		}
+	}
+
+	return time.Duration(remaining * float64(time.Second))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// penaltyAfterSearchTransport 开始匹配后搜索状态中才出现排队惩罚
+type penaltyAfterSearchTransport struct {
+	*replayTransport
+	searching bool
+}
+
+// Do 实现RESTTransport
+func (t *penaltyAfterSearchTransport) Do(creds *LCUCredentials, method, path string, body []byte) (int, []byte, error) {
+	status, resp, err := t.replayTransport.Do(creds, method, path, body)
+	switch {
+	case method == "POST" && path == "/lol-lobby/v2/lobby/matchmaking/search":
+		t.searching = true
+	case method == "GET" && path == "/lol-matchmaking/v1/search" && t.searching:
+		return 200, []byte(`{"errors":[{"penaltyTimeRemaining":120}]}`), nil
+	}
+	return status, resp, err
+}
+
+// newRequeueTestConnector 创建处于房间中且开启自动重新排队的连接器
+func newRequeueTestConnector(transport RESTTransport) *LCUConnector {
+	config := DefaultConfig()
+	config.AutoRequeueEnabled = true
+	lcu := NewLCUConnector(&App{config: config}, WithTransport(transport), WithEventStream(newFakeEventStream()))
+	lcu.credentials = &LCUCredentials{Token: "test", Protocol: "https"}
+	lcu.status.ClientStatus = "Lobby"
+	lcu.setConnected(true)
+	return lcu
+}
+
+func TestRequeueDoesNotRescheduleAfterSuccessfulSearch(t *testing.T) {
+	transport := &penaltyAfterSearchTransport{replayTransport: &replayTransport{}}
+	lcu := newRequeueTestConnector(transport)
+	scheduled := 0
+	lcu.spawn = func(f func()) {
+		scheduled++
+		f()
+	}
+	lcu.wait = func(time.Duration, <-chan struct{}) bool { return true }
+
+	lcu.scheduleRequeue(requeueDelay)
+
+	result := &ReplayResult{Requests: transport.requests}
+	want := []ReplayRequest{{Method: "POST", Path: "/lol-lobby/v2/lobby/matchmaking/search"}}
+	if got := result.Mutations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mutations = %s, want %s", mustMarshal(t, got), mustMarshal(t, want))
+	}
+	if scheduled != 1 {
+		t.Errorf("scheduled %d requeues, want 1", scheduled)
+	}
+}
+
+func TestDisconnectCancelsPendingRequeue(t *testing.T) {
+	transport := &replayTransport{responses: map[string]json.RawMessage{}}
+	lcu := newRequeueTestConnector(transport)
+	done := make(chan bool, 1)
+	lcu.wait = func(d time.Duration, cancel <-chan struct{}) bool {
+		ok := waitOrCancel(d, cancel)
+		done <- ok
+		return ok
+	}
+
+	lcu.scheduleRequeue(time.Hour)
+	lcu.Disconnect()
+
+	select {
+	case ok := <-done:
+		if ok {
+			t.Error("pending requeue finished waiting, want cancelled")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("pending requeue was not cancelled by Disconnect")
+	}
+	if got := (&ReplayResult{Requests: transport.requests}).Mutations(); len(got) != 0 {
+		t.Errorf("mutations = %s, want none", mustMarshal(t, got))
+	}
+}
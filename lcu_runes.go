@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// managedRunePagePrefix AutoBP管理的符文页名称前缀
+const managedRunePagePrefix = "AutoBP"
+
+// perkPage 客户端中的符文页
+type perkPage struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	IsEditable      bool   `json:"isEditable"`
+	IsDeletable     bool   `json:"isDeletable"`
+	Current         bool   `json:"current"`
+	PrimaryStyleID  int    `json:"primaryStyleId"`
+	SubStyleID      int    `json:"subStyleId"`
+	SelectedPerkIDs []int  `json:"selectedPerkIds"`
+}
+
+// perkInventory 符文页数量上限
+type perkInventory struct {
+	OwnedPageCount int `json:"ownedPageCount"`
+}
+
+// applyRunePage 为锁定的英雄创建或替换AutoBP管理的符文页并设为当前页
+func (lcu *LCUConnector) applyRunePage(championID int, position string) {
+	if lcu.app.runeLibrary == nil {
+		return
+	}
+	page, ok := lcu.app.runeLibrary.Get(championID, position)
+	if !ok {
+		fmt.Printf("[INFO] No rune page configured for champion %d\n", championID)
+		return
+	}
+	if err := page.Validate(); err != nil {
+		fmt.Printf("[ERROR] Invalid rune page for champion %d: %v\n", championID, err)
+		return
+	}
+
+	var pages []perkPage
+	if err := lcu.RequestJSON("GET", "/lol-perks/v1/pages", nil, &pages); err != nil {
+		fmt.Printf("[ERROR] Failed to get rune pages: %v\n", err)
+		return
+	}
+
+	name := page.Name
+	if name == "" {
+		name = fmt.Sprintf("%s %d", managedRunePagePrefix, championID)
+	} else if !strings.HasPrefix(name, managedRunePagePrefix) {
+		name = managedRunePagePrefix + " " + name
+	}
+
+	body := map[string]interface{}{
+		"name":            name,
+		"primaryStyleId":  page.PrimaryStyleID,
+		"subStyleId":      page.SubStyleID,
+		"selectedPerkIds": page.SelectedPerkIDs,
+		"current":         true,
+	}
+
+	// 优先复用AutoBP管理的符文页
+	var managed *perkPage
+	editable := 0
+	for i := range pages {
+		if !pages[i].IsDeletable {
+			continue
+		}
+		editable++
+		if managed == nil && strings.HasPrefix(pages[i].Name, managedRunePagePrefix) {
+			managed = &pages[i]
+		}
+	}
+
+	pageID := 0
+	if managed != nil {
+		// 直接替换AutoBP管理的符文页
+		body["id"] = managed.ID
+		if _, err := lcu.request("PUT", fmt.Sprintf("/lol-perks/v1/pages/%d", managed.ID), body); err != nil {
+			fmt.Printf("[ERROR] Failed to replace rune page %d: %v\n", managed.ID, err)
+			return
+		}
+		pageID = managed.ID
+	} else {
+		inventory := &perkInventory{}
+		if err := lcu.RequestJSON("GET", "/lol-perks/v1/inventory", nil, inventory); err != nil {
+			fmt.Printf("[ERROR] Failed to get rune page inventory: %v\n", err)
+			return
+		}
+		if editable >= inventory.OwnedPageCount {
+			fmt.Printf("[INFO] Rune page limit (%d) reached and no AutoBP page to reuse, skipping rune import\n", inventory.OwnedPageCount)
+			lcu.app.emitEvent("rune-import-skipped", "rune page limit reached")
+			return
+		}
+
+		created := &perkPage{}
+		if err := lcu.RequestJSON("POST", "/lol-perks/v1/pages", body, created); err != nil {
+			fmt.Printf("[ERROR] Failed to create rune page: %v\n", err)
+			return
+		}
+		pageID = created.ID
+	}
+
+	if pageID > 0 {
+		if _, err := lcu.request("PUT", "/lol-perks/v1/currentpage", pageID); err != nil {
+			fmt.Printf("[ERROR] Failed to set current rune page: %v\n", err)
+			return
+		}
+	}
+
+	fmt.Printf("[INFO] Imported rune page %q for champion %d\n", name, championID)
+}
+
+// fetchCurrentRunePage 获取客户端当前的符文页
+func fetchCurrentRunePage(lcu jsonRequester) (*RunePage, error) {
+	current := &perkPage{}
+	if err := lcu.RequestJSON("GET", "/lol-perks/v1/currentpage", nil, current); err != nil {
+		return nil, err
+	}
+	return &RunePage{
+		Name:            strings.TrimSpace(strings.TrimPrefix(current.Name, managedRunePagePrefix)),
+		PrimaryStyleID:  current.PrimaryStyleID,
+		SubStyleID:      current.SubStyleID,
+		SelectedPerkIDs: current.SelectedPerkIDs,
+	}, nil
+}
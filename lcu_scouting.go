@@ -0,0 +1,274 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// scoutCacheTTL 侦察数据的缓存时间
+	scoutCacheTTL = 10 * time.Minute
+	// scoutRetryTTL 有请求失败的侦察数据的缓存时间，过期后重新获取
+	scoutRetryTTL = 30 * time.Second
+	// scoutRequestInterval 侦察时两次LCU请求的最小间隔
+	scoutRequestInterval = 100 * time.Millisecond
+	// scoutFetchTimeout 正在获取的侦察数据超过该时间未完成时重新获取
+	scoutFetchTimeout = time.Minute
+)
+
+// scoutTarget 选人阶段中需要侦察的玩家
+type scoutTarget struct {
+	PUUID      string
+	CellID     int
+	Team       string
+	Position   string
+	ChampionID int
+}
+
+// scoutCacheEntry 缓存的侦察数据，data为nil表示正在获取
+type scoutCacheEntry struct {
+	data      *scoutData
+	failed    bool      // 获取时有请求失败
+	startedAt time.Time // 开始获取的时间
+	fetchedAt time.Time
+}
+
+// fresh 缓存是否仍然有效，正在获取的数据超时后视为无效，有请求失败的数据只缓存较短时间
+func (e *scoutCacheEntry) fresh(now time.Time) bool {
+	if e.data == nil {
+		return now.Sub(e.startedAt) < scoutFetchTimeout
+	}
+	if e.failed {
+		return now.Sub(e.fetchedAt) < scoutRetryTTL
+	}
+	return now.Sub(e.fetchedAt) < scoutCacheTTL
+}
+
+// scoutState 侦察数据缓存和请求限速
+type scoutState struct {
+	cache       map[string]*scoutCacheEntry
+	targets     []scoutTarget // 最近一次会话更新中的侦察对象
+	lastSummary string
+	nextRequest time.Time
+	mu          sync.Mutex
+}
+
+// handleScouting 侦察选人阶段的队友和可见的对手
+// 新玩家的数据在后台获取，获取完成后重新发送侦察结果
+func (lcu *LCUConnector) handleScouting(data map[string]interface{}, localCellID int) {
+	if !lcu.app.config.ScoutingEnabled {
+		return
+	}
+
+	targets := lcu.getScoutTargets(data, localCellID)
+	if len(targets) == 0 {
+		return
+	}
+
+	var missing []string
+	now := time.Now()
+	lcu.scout.mu.Lock()
+	if lcu.scout.cache == nil {
+		lcu.scout.cache = make(map[string]*scoutCacheEntry)
+	}
+	lcu.scout.targets = targets
+	for _, target := range targets {
+		if entry, ok := lcu.scout.cache[target.PUUID]; ok && entry.fresh(now) {
+			continue
+		}
+		lcu.scout.cache[target.PUUID] = &scoutCacheEntry{startedAt: now}
+		missing = append(missing, target.PUUID)
+	}
+	lcu.scout.mu.Unlock()
+
+	lcu.emitScouting(targets)
+
+	if len(missing) == 0 {
+		return
+	}
+	lcu.spawn(func() {
+		for _, puuid := range missing {
+			scouted, complete := lcu.scoutPlayer(puuid)
+			lcu.scout.mu.Lock()
+			lcu.scout.cache[puuid] = &scoutCacheEntry{data: scouted, failed: !complete, fetchedAt: time.Now()}
+			lcu.scout.mu.Unlock()
+		}
+		// 获取期间会话可能已更新（例如队友换了英雄），使用最新的侦察对象
+		lcu.scout.mu.Lock()
+		latest := lcu.scout.targets
+		lcu.scout.mu.Unlock()
+		if len(latest) > 0 {
+			lcu.emitScouting(latest)
+		}
+	})
+}
+
+// emitScouting 生成侦察结果，与上次发送的不同时通知前端
+func (lcu *LCUConnector) emitScouting(targets []scoutTarget) {
+	reports := make([]ScoutReport, 0, len(targets))
+	lcu.scout.mu.Lock()
+	for _, target := range targets {
+		var scouted *scoutData
+		if entry, ok := lcu.scout.cache[target.PUUID]; ok {
+			scouted = entry.data
+		}
+		reports = append(reports, buildScoutReport(ScoutReport{
+			PUUID:      target.PUUID,
+			Team:       target.Team,
+			CellID:     target.CellID,
+			Position:   target.Position,
+			ChampionID: target.ChampionID,
+		}, scouted))
+	}
+
+	encoded, err := json.Marshal(reports)
+	if err != nil || string(encoded) == lcu.scout.lastSummary {
+		lcu.scout.mu.Unlock()
+		return
+	}
+	lcu.scout.lastSummary = string(encoded)
+	lcu.scout.mu.Unlock()
+
+	lcu.app.emitEvent("scouting-summary", reports)
+}
+
+// resetScouting 进入新的选人阶段时重新发送侦察结果，缓存的数据继续保留
+func (lcu *LCUConnector) resetScouting() {
+	lcu.scout.mu.Lock()
+	defer lcu.scout.mu.Unlock()
+	lcu.scout.targets = nil
+	lcu.scout.lastSummary = ""
+}
+
+// getScoutTargets 获取除自己以外所有已知puuid的玩家
+// 排位赛中对手的puuid在选人阶段不可见，因此通常只有队友
+func (lcu *LCUConnector) getScoutTargets(data map[string]interface{}, localCellID int) []scoutTarget {
+	var targets []scoutTarget
+	for _, team := range []string{"myTeam", "theirTeam"} {
+		members, _ := data[team].([]interface{})
+		for _, member := range members {
+			memberMap, ok := member.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			puuid, _ := memberMap["puuid"].(string)
+			cellID, ok := memberMap["cellId"].(float64)
+			if puuid == "" || !ok || (team == "myTeam" && int(cellID) == localCellID) {
+				continue
+			}
+
+			target := scoutTarget{PUUID: puuid, CellID: int(cellID), Team: "ally"}
+			if team == "theirTeam" {
+				target.Team = "enemy"
+			}
+			target.Position, _ = memberMap["assignedPosition"].(string)
+			if championID, ok := memberMap["championId"].(float64); ok && championID > 0 {
+				target.ChampionID = int(championID)
+			} else if intent, ok := memberMap["championPickIntent"].(float64); ok && intent > 0 {
+				target.ChampionID = int(intent)
+			}
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// scoutRequestJSON 限速后请求LCU接口
+func (lcu *LCUConnector) scoutRequestJSON(path string, out interface{}) error {
+	lcu.scout.mu.Lock()
+	now := time.Now()
+	wait := time.Duration(0)
+	if lcu.scout.nextRequest.After(now) {
+		wait = lcu.scout.nextRequest.Sub(now)
+		lcu.scout.nextRequest = lcu.scout.nextRequest.Add(scoutRequestInterval)
+	} else {
+		lcu.scout.nextRequest = now.Add(scoutRequestInterval)
+	}
+	lcu.scout.mu.Unlock()
+
+	if wait > 0 {
+		lcu.sleep(wait)
+	}
+	return lcu.requestJSON("GET", path, nil, out)
+}
+
+// scoutRequester 经过侦察限速的jsonRequester，用于获取战绩和英雄成就
+type scoutRequester struct {
+	lcu *LCUConnector
+}
+
+// RequestJSON 限速后请求LCU接口
+func (r scoutRequester) RequestJSON(method, path string, body interface{}, out interface{}) error {
+	return r.lcu.scoutRequestJSON(path, out)
+}
+
+// scoutPlayer 获取一名玩家的召唤师信息、单双排段位、最近战绩和英雄成就，单项失败不影响其他数据
+// 第二个返回值表示所有请求是否都成功
+func (lcu *LCUConnector) scoutPlayer(puuid string) (*scoutData, bool) {
+	scouted := &scoutData{}
+	requester := scoutRequester{lcu: lcu}
+	complete := true
+
+	var summoner struct {
+		GameName      string `json:"gameName"`
+		DisplayName   string `json:"displayName"`
+		SummonerID    int64  `json:"summonerId"`
+		SummonerLevel int    `json:"summonerLevel"`
+	}
+	if err := lcu.scoutRequestJSON("/lol-summoner/v2/summoners/puuid/"+puuid, &summoner); err != nil {
+		fmt.Printf("[ERROR] Failed to get summoner for scouting: %v\n", err)
+		complete = false
+	}
+	scouted.Name = summoner.GameName
+	if scouted.Name == "" {
+		scouted.Name = summoner.DisplayName
+	}
+	scouted.SummonerLevel = summoner.SummonerLevel
+
+	var ranked struct {
+		Queues []struct {
+			QueueType    string `json:"queueType"`
+			Tier         string `json:"tier"`
+			Division     string `json:"division"`
+			LeaguePoints int    `json:"leaguePoints"`
+			Wins         int    `json:"wins"`
+			Losses       int    `json:"losses"`
+		} `json:"queues"`
+	}
+	if err := lcu.scoutRequestJSON("/lol-ranked/v1/ranked-stats/"+puuid, &ranked); err != nil {
+		fmt.Printf("[ERROR] Failed to get ranked stats for scouting: %v\n", err)
+		complete = false
+	}
+	for _, queue := range ranked.Queues {
+		if queue.QueueType == "RANKED_SOLO_5x5" {
+			scouted.Ranked = &RankedStats{
+				QueueType:    queue.QueueType,
+				Tier:         queue.Tier,
+				Rank:         queue.Division,
+				LeaguePoints: queue.LeaguePoints,
+				Wins:         queue.Wins,
+				Losses:       queue.Losses,
+			}
+		}
+	}
+
+	matches, err := fetchMatchHistory(requester, puuid, 0)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get match history for scouting: %v\n", err)
+		complete = false
+	}
+	scouted.Matches = matches
+
+	if summoner.SummonerID > 0 {
+		masteries, err := fetchChampionMastery(requester, summoner.SummonerID)
+		if err != nil {
+			fmt.Printf("[ERROR] Failed to get champion mastery for scouting: %v\n", err)
+			complete = false
+		}
+		scouted.Masteries = masteries
+	}
+
+	return scouted, complete
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// newScoutingTestConnector 创建后台任务由测试手动执行的连接器
+func newScoutingTestConnector(t *testing.T) (*LCUConnector, *[]func()) {
+	t.Helper()
+	responses := map[string]json.RawMessage{
+		"GET /lol-summoner/v2/summoners/puuid/ally-1": json.RawMessage(`{"gameName":"Ally","summonerLevel":30}`),
+	}
+	lcu := NewLCUConnector(&App{config: DefaultConfig()}, WithTransport(&replayTransport{responses: responses}))
+	lcu.credentials = &LCUCredentials{Token: "test", Protocol: "https"}
+	lcu.setConnected(true)
+	lcu.sleep = func(time.Duration) {}
+
+	var pending []func()
+	lcu.spawn = func(f func()) { pending = append(pending, f) }
+	return lcu, &pending
+}
+
+// scoutSession 只有自己和一名队友的选人会话
+func scoutSession(allyChampion int) map[string]interface{} {
+	return map[string]interface{}{
+		"localPlayerCellId": float64(0),
+		"myTeam": []interface{}{
+			map[string]interface{}{"cellId": float64(0), "puuid": "me"},
+			map[string]interface{}{"cellId": float64(1), "puuid": "ally-1", "championId": float64(allyChampion)},
+		},
+	}
+}
+
+// lastScoutReports 解析最近一次发送的侦察结果
+func lastScoutReports(t *testing.T, lcu *LCUConnector) []ScoutReport {
+	t.Helper()
+	lcu.scout.mu.Lock()
+	summary := lcu.scout.lastSummary
+	lcu.scout.mu.Unlock()
+
+	var reports []ScoutReport
+	if err := json.Unmarshal([]byte(summary), &reports); err != nil {
+		t.Fatalf("failed to parse scouting summary %q: %v", summary, err)
+	}
+	return reports
+}
+
+func TestScoutingEmitsLatestTargets(t *testing.T) {
+	lcu, pending := newScoutingTestConnector(t)
+
+	lcu.handleScouting(scoutSession(0), 0)
+	// 获取完成前队友选择了英雄，此时数据仍在获取中，不应重复获取
+	lcu.handleScouting(scoutSession(157), 0)
+	if len(*pending) != 1 {
+		t.Fatalf("started %d background fetches, want 1", len(*pending))
+	}
+
+	(*pending)[0]()
+
+	reports := lastScoutReports(t, lcu)
+	if len(reports) != 1 {
+		t.Fatalf("reports = %+v, want one ally", reports)
+	}
+	if reports[0].ChampionID != 157 || reports[0].Name != "Ally" {
+		t.Errorf("report = %+v, want latest champion 157 with fetched name", reports[0])
+	}
+}
+
+func TestScoutingRefetchesStaleInFlightEntries(t *testing.T) {
+	lcu, pending := newScoutingTestConnector(t)
+
+	lcu.handleScouting(scoutSession(0), 0)
+	if len(*pending) != 1 {
+		t.Fatalf("started %d background fetches, want 1", len(*pending))
+	}
+
+	// 上一次获取没有完成（例如请求卡住），超时后应重新获取
+	lcu.scout.mu.Lock()
+	lcu.scout.cache["ally-1"].startedAt = time.Now().Add(-2 * scoutFetchTimeout)
+	lcu.scout.mu.Unlock()
+
+	lcu.handleScouting(scoutSession(0), 0)
+	if len(*pending) != 2 {
+		t.Fatalf("started %d background fetches, want 2", len(*pending))
+	}
+}
+
+func TestScoutingRetriesFailedLookupsSooner(t *testing.T) {
+	lcu, pending := newScoutingTestConnector(t)
+
+	// 测试连接器只有召唤师信息的响应，段位和战绩请求会失败
+	lcu.handleScouting(scoutSession(0), 0)
+	(*pending)[0]()
+
+	lcu.scout.mu.Lock()
+	entry := lcu.scout.cache["ally-1"]
+	if !entry.failed {
+		lcu.scout.mu.Unlock()
+		t.Fatalf("cache entry is not marked failed after failed requests")
+	}
+	entry.fetchedAt = time.Now().Add(-2 * scoutRetryTTL)
+	lcu.scout.mu.Unlock()
+
+	lcu.handleScouting(scoutSession(0), 0)
+	if len(*pending) != 2 {
+		t.Fatalf("started %d background fetches, want failed lookup retried", len(*pending))
+	}
+}
+
+func TestScoutingCachesCompleteLookups(t *testing.T) {
+	lcu, pending := newScoutingTestConnector(t)
+	transport := lcu.transport.(*replayTransport)
+	transport.responses["GET /lol-ranked/v1/ranked-stats/ally-1"] = json.RawMessage(`{"queues":[]}`)
+	transport.responses["GET /lol-match-history/v1/products/lol/ally-1/matches?begIndex=0&endIndex=19"] = json.RawMessage(`{"games":{"games":[]}}`)
+
+	lcu.handleScouting(scoutSession(0), 0)
+	(*pending)[0]()
+
+	lcu.scout.mu.Lock()
+	entry := lcu.scout.cache["ally-1"]
+	failed := entry.failed
+	entry.fetchedAt = time.Now().Add(-2 * scoutRetryTTL)
+	lcu.scout.mu.Unlock()
+	if failed {
+		t.Fatalf("cache entry is marked failed although all requests succeeded")
+	}
+
+	lcu.handleScouting(scoutSession(0), 0)
+	if len(*pending) != 1 {
+		t.Errorf("started %d background fetches, want complete lookup kept for the full TTL", len(*pending))
+	}
+}
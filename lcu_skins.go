@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+)
+
+// RandomSkin 皮肤配置为该值时随机选择一个已拥有的皮肤
+const RandomSkin = -1
+
+// carouselSkin /lol-champ-select/v1/skin-carousel-skins 返回的皮肤
+type carouselSkin struct {
+	ID         int            `json:"id"`
+	ChampionID int            `json:"championId"`
+	Name       string         `json:"name"`
+	IsBase     bool           `json:"isBase"`
+	Unlocked   bool           `json:"unlocked"`
+	Disabled   bool           `json:"disabled"`
+	ChildSkins []carouselSkin `json:"childSkins"` // 炫彩
+}
+
+// handleSkinSelection 在最终阶段为锁定的英雄选择皮肤
+func (lcu *LCUConnector) handleSkinSelection(data map[string]interface{}, localCellID int) {
+	championID := lcu.getLockedChampion(data, localCellID)
+	if championID <= 0 {
+		return
+	}
+
+	skinID, ok := lcu.app.config.PreferredSkins[strconv.Itoa(championID)]
+	if !ok || skinID == 0 {
+		return
+	}
+
+	actionKey := fmt.Sprintf("skin_%d", championID)
+	if lcu.isActionProcessed(actionKey) {
+		return
+	}
+	lcu.addProcessedAction(actionKey)
+
+	lcu.spawn(func() { lcu.applySkin(championID, skinID) })
+}
+
+// applySkin 选择指定皮肤或随机已拥有的皮肤，未拥有时保持当前皮肤
+func (lcu *LCUConnector) applySkin(championID int, skinID int) {
+	var skins []carouselSkin
+	if err := lcu.requestJSON("GET", "/lol-champ-select/v1/skin-carousel-skins", nil, &skins); err != nil {
+		fmt.Printf("[ERROR] Failed to get skins: %v\n", err)
+		return
+	}
+
+	owned := make(map[int]bool)
+	var ownedSkins []int
+	for _, skin := range skins {
+		if skin.ChampionID != championID || !skin.Unlocked || skin.Disabled {
+			continue
+		}
+		owned[skin.ID] = true
+		if !skin.IsBase {
+			ownedSkins = append(ownedSkins, skin.ID)
+		}
+		for _, chroma := range skin.ChildSkins {
+			if chroma.Unlocked && !chroma.Disabled {
+				owned[chroma.ID] = true
+			}
+		}
+	}
+
+	if skinID == RandomSkin {
+		if len(ownedSkins) == 0 {
+			fmt.Printf("[INFO] No owned skins for champion %d, keeping default skin\n", championID)
+			return
+		}
+		skinID = ownedSkins[rand.Intn(len(ownedSkins))]
+	} else if !owned[skinID] {
+		fmt.Printf("[INFO] Preferred skin %d for champion %d is not owned, keeping current skin\n", skinID, championID)
+		return
+	}
+
+	selection := map[string]interface{}{
+		"selectedSkinId": skinID,
+	}
+	if _, err := lcu.request("PATCH", "/lol-champ-select/v1/session/my-selection", selection); err != nil {
+		fmt.Printf("[ERROR] Failed to select skin %d: %v\n", skinID, err)
+		return
+	}
+
+	fmt.Printf("[INFO] Selected skin %d for champion %d\n", skinID, championID)
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+)
+
+// applySummonerSpells 为锁定的英雄设置召唤师技能
+func (lcu *LCUConnector) applySummonerSpells(championID int, position string) {
+	spells, ok := lcu.app.config.GetSpellsForChampion(championID, position)
+	if !ok {
+		fmt.Printf("[INFO] No summoner spells configured for champion %d\n", championID)
+		return
+	}
+
+	gameMode := lcu.getCurrentGameMode()
+	if err := spells.Validate(gameMode, position); err != nil {
+		fmt.Printf("[INFO] Skipping summoner spells for champion %d: %v\n", championID, err)
+		return
+	}
+
+	selection := map[string]interface{}{
+		"spell1Id": spells.Spell1,
+		"spell2Id": spells.Spell2,
+	}
+	if _, err := lcu.request("PATCH", "/lol-champ-select/v1/session/my-selection", selection); err != nil {
+		fmt.Printf("[ERROR] Failed to set summoner spells: %v\n", err)
+		return
+	}
+
+	fmt.Printf("[INFO] Set summoner spells %d/%d for champion %d\n", spells.Spell1, spells.Spell2, championID)
+}
+
+// getCurrentGameMode 获取当前游戏模式，如CLASSIC、ARAM
+func (lcu *LCUConnector) getCurrentGameMode() string {
+	session, err := lcu.request("GET", "/lol-gameflow/v1/session", nil)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to get gameflow session: %v\n", err)
+		return ""
+	}
+
+	gameData, _ := session["gameData"].(map[string]interface{})
+	queue, _ := gameData["queue"].(map[string]interface{})
+	gameMode, _ := queue["gameMode"].(string)
+	return gameMode
+}
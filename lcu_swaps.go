@@ -0,0 +1,207 @@
+package main
+
+import (
+	"fmt"
+)
+
+// 交换请求的处理策略
+const (
+	SwapPolicyOff     = "off"     // 不处理，由玩家决定
+	SwapPolicyAccept  = "accept"  // 总是接受
+	SwapPolicyDecline = "decline" // 总是拒绝
+	SwapPolicyAuto    = "auto"    // 交换后结果更好时接受，否则拒绝
+)
+
+// SwapDecision 交换请求的处理结果，发送给前端
+type SwapDecision struct {
+	Kind     string `json:"kind"` // trade, position, pickOrder
+	ID       int    `json:"id"`
+	CellID   int    `json:"cellId"`
+	Accepted bool   `json:"accepted"`
+	Reason   string `json:"reason"`
+}
+
+// swapKind 一种交换请求的LCU字段和接口
+type swapKind struct {
+	name     string
+	field    string
+	endpoint string
+	policy   func(c *Config) string
+	evaluate func(lcu *LCUConnector, data map[string]interface{}, localCellID, otherCellID int) (bool, string)
+}
+
+var swapKinds = []swapKind{
+	{
+		name:     "trade",
+		field:    "trades",
+		endpoint: "trades",
+		policy:   func(c *Config) string { return c.TradePolicy },
+		evaluate: (*LCUConnector).evaluateTrade,
+	},
+	{
+		name:     "position",
+		field:    "positionSwaps",
+		endpoint: "position-swaps",
+		policy:   func(c *Config) string { return c.PositionSwapPolicy },
+		evaluate: (*LCUConnector).evaluatePositionSwap,
+	},
+	{
+		name:     "pickOrder",
+		field:    "pickOrderSwaps",
+		endpoint: "pick-order-swaps",
+		policy:   func(c *Config) string { return c.PickOrderSwapPolicy },
+		evaluate: (*LCUConnector).evaluatePickOrderSwap,
+	},
+}
+
+// handleSwapRequests 处理收到的英雄交换、位置交换和选人顺序交换请求
+func (lcu *LCUConnector) handleSwapRequests(data map[string]interface{}, localCellID int) {
+	for _, kind := range swapKinds {
+		policy := kind.policy(lcu.app.config)
+		if policy == "" || policy == SwapPolicyOff {
+			continue
+		}
+
+		requests, _ := data[kind.field].([]interface{})
+		for _, request := range requests {
+			requestMap, ok := request.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			state, _ := requestMap["state"].(string)
+			if state != "RECEIVED" {
+				continue
+			}
+			id, _ := requestMap["id"].(float64)
+			cellID, _ := requestMap["cellId"].(float64)
+
+			actionKey := fmt.Sprintf("%s_swap_%d", kind.name, int(id))
+			if lcu.isActionProcessed(actionKey) {
+				continue
+			}
+			lcu.addProcessedAction(actionKey)
+
+			decision := SwapDecision{
+				Kind:   kind.name,
+				ID:     int(id),
+				CellID: int(cellID),
+			}
+			switch policy {
+			case SwapPolicyAccept:
+				decision.Accepted, decision.Reason = true, "policy: always accept"
+			case SwapPolicyDecline:
+				decision.Accepted, decision.Reason = false, "policy: always decline"
+			default:
+				decision.Accepted, decision.Reason = kind.evaluate(lcu, data, localCellID, int(cellID))
+			}
+
+			lcu.respondSwap(kind, decision)
+		}
+	}
+}
+
+// respondSwap 接受或拒绝交换请求并通知前端
+func (lcu *LCUConnector) respondSwap(kind swapKind, decision SwapDecision) {
+	verb, result := "decline", "Declined"
+	if decision.Accepted {
+		verb, result = "accept", "Accepted"
+	}
+
+	path := fmt.Sprintf("/lol-champ-select/v1/session/%s/%d/%s", kind.endpoint, decision.ID, verb)
+	if _, err := lcu.request("POST", path, nil); err != nil {
+		fmt.Printf("[ERROR] Failed to %s %s swap %d: %v\n", verb, kind.name, decision.ID, err)
+		return
+	}
+
+	fmt.Printf("[INFO] %s %s swap from cell %d: %s\n", result, kind.name, decision.CellID, decision.Reason)
+	lcu.app.emitEvent("swap-decision", decision)
+}
+
+// evaluateTrade 对方的英雄在我们的英雄池中排名更靠前时接受英雄交换
+func (lcu *LCUConnector) evaluateTrade(data map[string]interface{}, localCellID, otherCellID int) (bool, string) {
+	position := lcu.getPlayerAssignedPosition(data, localCellID)
+	candidates := lcu.getPickCandidates(position)
+
+	ours := getCellChampion(data, localCellID)
+	theirs := getCellChampion(data, otherCellID)
+	ourRank, theirRank := priorityRank(candidates, ours), priorityRank(candidates, theirs)
+
+	if theirRank < ourRank {
+		return true, fmt.Sprintf("champion %d ranks higher than %d in our pool", theirs, ours)
+	}
+	return false, fmt.Sprintf("champion %d does not rank higher than %d in our pool", theirs, ours)
+}
+
+// evaluatePositionSwap 对方的位置在我们的位置偏好中排名更靠前时接受位置交换
+func (lcu *LCUConnector) evaluatePositionSwap(data map[string]interface{}, localCellID, otherCellID int) (bool, string) {
+	ours := lcu.getPlayerAssignedPosition(data, localCellID)
+	theirs := lcu.getPlayerAssignedPosition(data, otherCellID)
+	ourRank := lcu.app.config.GetPositionRank(ours)
+	theirRank := lcu.app.config.GetPositionRank(theirs)
+
+	if theirRank < ourRank {
+		return true, fmt.Sprintf("position %s is preferred over %s", theirs, ours)
+	}
+	return false, fmt.Sprintf("position %s is not preferred over %s", theirs, ours)
+}
+
+// evaluatePickOrderSwap 对方选人顺序更靠前时接受，更早选人更容易拿到英雄池中的首选英雄
+func (lcu *LCUConnector) evaluatePickOrderSwap(data map[string]interface{}, localCellID, otherCellID int) (bool, string) {
+	ours := getPickOrder(data, localCellID)
+	theirs := getPickOrder(data, otherCellID)
+
+	if theirs >= 0 && (ours < 0 || theirs < ours) {
+		return true, fmt.Sprintf("pick order %d is earlier than %d", theirs, ours)
+	}
+	return false, fmt.Sprintf("pick order %d is not earlier than %d", theirs, ours)
+}
+
+// priorityRank 获取英雄在优先级列表中的排名，不在列表中时排在最后
+func priorityRank(priorities []int, championID int) int {
+	for i, id := range priorities {
+		if id == championID {
+			return i
+		}
+	}
+	return len(priorities)
+}
+
+// getCellChampion 获取指定Cell当前的英雄
+func getCellChampion(data map[string]interface{}, cellID int) int {
+	myTeam, _ := data["myTeam"].([]interface{})
+	for _, player := range myTeam {
+		if playerMap, ok := player.(map[string]interface{}); ok {
+			if id, ok := playerMap["cellId"].(float64); ok && int(id) == cellID {
+				championID, _ := playerMap["championId"].(float64)
+				return int(championID)
+			}
+		}
+	}
+	return -1
+}
+
+// getPickOrder 获取指定Cell的选人顺序，从0开始
+func getPickOrder(data map[string]interface{}, cellID int) int {
+	order := 0
+	actions, _ := data["actions"].([]interface{})
+	for _, actionGroup := range actions {
+		group, ok := actionGroup.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, action := range group {
+			actionMap, ok := action.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if aType, _ := actionMap["type"].(string); aType != "pick" {
+				continue
+			}
+			if actorCellID, ok := actionMap["actorCellId"].(float64); ok && int(actorCellID) == cellID {
+				return order
+			}
+			order++
+		}
+	}
+	return -1
+}
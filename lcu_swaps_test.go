@@ -0,0 +1,28 @@
+package main
+
+import "testing"
+
+func TestEvaluateTradeUsesMasteryOrder(t *testing.T) {
+	mastery := NewMasteryService()
+	mastery.masteries = map[int]ChampionMastery{20: {ChampionID: 20, ChampionPoints: 5000}}
+
+	// 英雄池中10排在20前面，按成就排序后20排在前面
+	config := &Config{
+		PositionChampionPools: map[string][]int{"TOP": {10, 20}},
+		PickOrderByMastery:    true,
+	}
+	lcu := NewLCUConnector(&App{config: config, mastery: mastery},
+		WithTransport(&replayTransport{}), WithEventStream(newFakeEventStream()))
+
+	data := map[string]interface{}{
+		"myTeam": []interface{}{
+			map[string]interface{}{"cellId": float64(0), "championId": float64(10), "assignedPosition": "top"},
+			map[string]interface{}{"cellId": float64(1), "championId": float64(20), "assignedPosition": "jungle"},
+		},
+	}
+
+	accept, reason := lcu.evaluateTrade(data, 0, 1)
+	if !accept {
+		t.Errorf("evaluateTrade() = false (%s), want trade for the higher mastery champion accepted", reason)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+// fakeEventStream 不产生任何事件的事件流，关闭后Read返回错误
+type fakeEventStream struct {
+	closed chan struct{}
+	once   sync.Once
+}
+
+func newFakeEventStream() *fakeEventStream {
+	return &fakeEventStream{closed: make(chan struct{})}
+}
+
+func (s *fakeEventStream) Open(creds *LCUCredentials) error { return nil }
+
+func (s *fakeEventStream) Subscribe(event string) error { return nil }
+
+func (s *fakeEventStream) Read() (json.RawMessage, error) {
+	<-s.closed
+	return nil, fmt.Errorf("use of closed connection")
+}
+
+func (s *fakeEventStream) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	return nil
+}
+
+// fakeCredentialProvider 返回固定凭据的提供者
+type fakeCredentialProvider struct {
+	name string
+}
+
+func (p *fakeCredentialProvider) Name() string { return p.name }
+
+func (p *fakeCredentialProvider) Find() (*LCUCredentials, error) {
+	return &LCUCredentials{Port: 1, Token: "test", Protocol: "https"}, nil
+}
+
+func TestGetStatusReportsCredentialSource(t *testing.T) {
+	transport := &replayTransport{responses: map[string]json.RawMessage{
+		"GET /lol-gameflow/v1/gameflow-phase": json.RawMessage(`"Lobby"`),
+	}}
+	lcu := NewLCUConnector(&App{config: DefaultConfig()},
+		WithTransport(transport),
+		WithEventStream(newFakeEventStream()),
+		WithCredentialProviders(&fakeCredentialProvider{name: CredentialSourceLockfile}),
+	)
+	lcu.spawn = func(f func()) { f() }
+
+	if err := lcu.Connect(); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	status := lcu.GetStatus()
+	if status.CredentialSource != CredentialSourceLockfile {
+		t.Errorf("CredentialSource = %q, want %q", status.CredentialSource, CredentialSourceLockfile)
+	}
+	if status.ClientStatus != "Lobby" {
+		t.Errorf("ClientStatus = %q, want Lobby", status.ClientStatus)
+	}
+
+	lcu.Disconnect()
+	if source := lcu.GetStatus().CredentialSource; source != "" {
+		t.Errorf("CredentialSource after Disconnect = %q, want empty", source)
+	}
+}
+
+func TestWithHostBuildsDefaultTransport(t *testing.T) {
+	lcu := NewLCUConnector(&App{config: DefaultConfig()}, WithHost("192.168.1.10"))
+
+	transport, ok := lcu.transport.(*httpTransport)
+	if !ok || transport.host != "192.168.1.10" {
+		t.Fatalf("transport = %#v, want httpTransport on 192.168.1.10", lcu.transport)
+	}
+	events, ok := lcu.events.(*websocketStream)
+	if !ok || events.host != "192.168.1.10" {
+		t.Fatalf("events = %#v, want websocketStream on 192.168.1.10", lcu.events)
+	}
+
+	// 显式注入的传输层不会被WithHost覆盖
+	injected := &replayTransport{}
+	lcu = NewLCUConnector(&App{config: DefaultConfig()}, WithHost("192.168.1.10"), WithTransport(injected))
+	if lcu.transport != injected {
+		t.Errorf("transport = %#v, want injected transport", lcu.transport)
+	}
+}
+
+func TestLCUHostFromConfig(t *testing.T) {
+	if got := lcuHost(nil); got != defaultLCUHost {
+		t.Errorf("lcuHost(nil) = %q, want %q", got, defaultLCUHost)
+	}
+	config := DefaultConfig()
+	config.LCUHost = "10.0.0.2"
+	if got := lcuHost(config); got != "10.0.0.2" {
+		t.Errorf("lcuHost() = %q, want 10.0.0.2", got)
+	}
+}
+
+// fakeConnector 不依赖LCUConnector的Connector实现，请求返回预设的JSON
+type fakeConnector struct {
+	responses map[string]string
+}
+
+func (c *fakeConnector) Connect() error                           { return nil }
+func (c *fakeConnector) Disconnect()                              {}
+func (c *fakeConnector) IsConnected() bool                        { return true }
+func (c *fakeConnector) GetStatus() *LCUStatus                    { return &LCUStatus{Connected: true} }
+func (c *fakeConnector) GetCounterPicks() []CounterPickSuggestion { return nil }
+
+func (c *fakeConnector) Request(method, path string, body interface{}) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	err := c.RequestJSON(method, path, body, &result)
+	return result, err
+}
+
+func (c *fakeConnector) RequestJSON(method, path string, body interface{}, out interface{}) error {
+	response, ok := c.responses[method+" "+path]
+	if !ok {
+		return fmt.Errorf("HTTP 404: %s", path)
+	}
+	return json.Unmarshal([]byte(response), out)
+}
+
+func TestAppRequestsThroughConnector(t *testing.T) {
+	app := &App{config: DefaultConfig()}
+	app.lcuConnector = &fakeConnector{responses: map[string]string{
+		"GET /lol-game-queues/v1/queues": `[
+			{"id": 420, "queueAvailability": "Available"},
+			{"id": 1700, "queueAvailability": "PlatformDisabled"}
+		]`,
+	}}
+
+	queues, err := app.GetQueues()
+	if err != nil {
+		t.Fatalf("GetQueues() error = %v", err)
+	}
+	if len(queues) != 1 || queues[0].ID != 420 {
+		t.Errorf("GetQueues() = %+v, want only queue 420", queues)
+	}
+}
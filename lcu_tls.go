@@ -0,0 +1,95 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	_ "embed"
+	"fmt"
+	"net"
+	"sync"
+)
+
+// riotRootPEM LCU、Riot客户端和游戏内接口使用的自签名根证书
+//
+//go:embed certs/riotgames.pem
+var riotRootPEM []byte
+
+var (
+	riotRootOnce sync.Once
+	riotRoots    *x509.CertPool
+)
+
+// riotRootPool 返回只包含Riot根证书的证书池
+func riotRootPool() *x509.CertPool {
+	riotRootOnce.Do(func() {
+		riotRoots = x509.NewCertPool()
+		if !riotRoots.AppendCertsFromPEM(riotRootPEM) {
+			fmt.Println("[ERROR] Failed to parse bundled Riot root certificate")
+		}
+	})
+	return riotRoots
+}
+
+// riotTLSConfig 返回连接本机Riot服务的TLS配置
+// insecure为true时跳过证书校验，仅作为证书异常时的应急选项
+func riotTLSConfig(host string, insecure bool) *tls.Config {
+	if insecure {
+		return &tls.Config{InsecureSkipVerify: true}
+	}
+	return newPinnedTLSConfig(host, riotRootPool())
+}
+
+// skipTLSVerify 配置中是否开启了跳过证书校验，没有配置时不跳过
+func skipTLSVerify(config *Config) bool {
+	return config != nil && config.InsecureSkipTLSVerify
+}
+
+// newPinnedTLSConfig 返回只信任指定根证书并校验主机地址的TLS配置
+// 客户端证书只在CN中写了127.0.0.1而没有SAN，标准库的主机名校验会失败，因此改为自行校验
+func newPinnedTLSConfig(host string, roots *x509.CertPool) *tls.Config {
+	return &tls.Config{
+		InsecureSkipVerify: true,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			return verifyPinnedCertificate(cs.PeerCertificates, host, roots)
+		},
+	}
+}
+
+// verifyPinnedCertificate 校验证书链由指定根证书签发，且证书属于指定主机
+func verifyPinnedCertificate(certs []*x509.Certificate, host string, roots *x509.CertPool) error {
+	if len(certs) == 0 {
+		return fmt.Errorf("no peer certificate presented")
+	}
+
+	leaf := certs[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("certificate not signed by Riot root: %w", err)
+	}
+
+	if leaf.Subject.CommonName == host {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		for _, certIP := range leaf.IPAddresses {
+			if certIP.Equal(ip) {
+				return nil
+			}
+		}
+	}
+	for _, name := range leaf.DNSNames {
+		if name == host {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("certificate is not valid for %s", host)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testCA 测试用的根证书
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCA{cert: cert, key: key}
+}
+
+// pool 只包含该根证书的证书池
+func (ca *testCA) pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+	return pool
+}
+
+// issue 签发只在CN中写主机地址、没有SAN的服务端证书，与客户端证书的格式一致
+func (ca *testCA) issue(t *testing.T, commonName string) tls.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// newTestTLSServer 使用指定证书启动HTTPS服务，返回服务和端口
+func newTestTLSServer(t *testing.T, cert tls.Certificate) (*httptest.Server, int) {
+	t.Helper()
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`"Lobby"`))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, port
+}
+
+func TestPinnedTLSConfig(t *testing.T) {
+	ca := newTestCA(t, "Test Riot Root")
+	otherCA := newTestCA(t, "Other Root")
+
+	tests := []struct {
+		name    string
+		cert    tls.Certificate
+		roots   *x509.CertPool
+		wantErr bool
+	}{
+		{name: "good root", cert: ca.issue(t, defaultLCUHost), roots: ca.pool()},
+		{name: "wrong root", cert: otherCA.issue(t, defaultLCUHost), roots: ca.pool(), wantErr: true},
+		{name: "wrong host", cert: ca.issue(t, "192.168.0.1"), roots: ca.pool(), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, port := newTestTLSServer(t, tt.cert)
+			transport := NewHTTPTransport(defaultLCUHost, newPinnedTLSConfig(defaultLCUHost, tt.roots))
+
+			status, body, err := transport.Do(&LCUCredentials{Port: port, Token: "test", Protocol: "https"}, "GET", "/lol-gameflow/v1/gameflow-phase", nil)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("Do() succeeded with status %d, want TLS error", status)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Do() error = %v", err)
+			}
+			if status != http.StatusOK || string(body) != `"Lobby"` {
+				t.Errorf("Do() = %d %s, want 200 \"Lobby\"", status, body)
+			}
+		})
+	}
+}
+
+func TestVerifyPinnedCertificateNoCertificate(t *testing.T) {
+	if err := verifyPinnedCertificate(nil, defaultLCUHost, x509.NewCertPool()); err == nil {
+		t.Error("verifyPinnedCertificate() with no certificates should fail")
+	}
+}
+
+func TestNewLCUConnectorWithoutConfig(t *testing.T) {
+	if lcu := NewLCUConnector(&App{}); lcu.transport == nil {
+		t.Error("NewLCUConnector() without config should use the default transport")
+	}
+	if rc := NewRiotClientConnector(nil); rc.transport == nil {
+		t.Error("NewRiotClientConnector() without config should use the default transport")
+	}
+}
+
+func TestBundledRiotRoot(t *testing.T) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(riotRootPEM) {
+		t.Fatal("bundled Riot root certificate could not be parsed")
+	}
+}
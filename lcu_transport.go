@@ -0,0 +1,225 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// defaultLCUHost LCU默认监听地址
+const defaultLCUHost = "127.0.0.1"
+
+// lcuHost 返回配置中的LCU地址，没有配置时使用默认地址
+func lcuHost(config *Config) string {
+	if config != nil && config.LCUHost != "" {
+		return config.LCUHost
+	}
+	return defaultLCUHost
+}
+
+// RESTTransport LCU REST接口的传输层
+type RESTTransport interface {
+	// Do 发送请求，返回状态码和响应体
+	Do(creds *LCUCredentials, method, path string, body []byte) (int, []byte, error)
+}
+
+// EventStream LCU的事件流
+type EventStream interface {
+	// Open 建立连接
+	Open(creds *LCUCredentials) error
+	// Subscribe 订阅事件
+	Subscribe(event string) error
+	// Read 阻塞读取下一条消息，连接关闭时返回错误
+	Read() (json.RawMessage, error)
+	// Close 关闭连接，可重复调用
+	Close() error
+}
+
+// LCUOption LCU连接器的构造选项
+type LCUOption func(*LCUConnector)
+
+// WithTransport 使用指定的REST传输层
+func WithTransport(transport RESTTransport) LCUOption {
+	return func(lcu *LCUConnector) {
+		lcu.transport = transport
+	}
+}
+
+// WithHost 使用指定的LCU地址创建默认传输层和事件流，显式注入的传输层和事件流不受影响
+func WithHost(host string) LCUOption {
+	return func(lcu *LCUConnector) {
+		lcu.host = host
+	}
+}
+
+// WithEventStream 使用指定的事件流
+func WithEventStream(events EventStream) LCUOption {
+	return func(lcu *LCUConnector) {
+		lcu.events = events
+	}
+}
+
+// basicAuth 生成LCU的Basic认证头
+func basicAuth(creds *LCUCredentials) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("riot:%s", creds.Token)))
+}
+
+// hostPort 拼接地址和端口
+func hostPort(host string, port int) string {
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// httpTransport 基于HTTP客户端的默认REST传输层
+type httpTransport struct {
+	client *http.Client
+	host   string
+}
+
+// NewHTTPTransport 创建默认的REST传输层
+func NewHTTPTransport(host string, tlsConfig *tls.Config) RESTTransport {
+	return &httpTransport{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig: tlsConfig,
+			},
+		},
+		host: host,
+	}
+}
+
+// Do 发送HTTP请求
+func (t *httpTransport) Do(creds *LCUCredentials, method, path string, body []byte) (int, []byte, error) {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+
+	url := fmt.Sprintf("%s://%s%s", creds.Protocol, hostPort(t.host, creds.Port), path)
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	req.Header.Set("Authorization", basicAuth(creds))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return resp.StatusCode, respBody, nil
+}
+
+// websocketStream 基于WebSocket的默认事件流
+type websocketStream struct {
+	host      string
+	tlsConfig *tls.Config
+	conn      *websocket.Conn
+	mu        sync.Mutex
+}
+
+// NewWebSocketStream 创建默认的事件流
+func NewWebSocketStream(host string, tlsConfig *tls.Config) EventStream {
+	return &websocketStream{host: host, tlsConfig: tlsConfig}
+}
+
+// Open 建立WebSocket连接
+func (s *websocketStream) Open(creds *LCUCredentials) error {
+	header := http.Header{}
+	header.Add("Authorization", basicAuth(creds))
+
+	scheme := "wss"
+	if creds.Protocol == "http" {
+		scheme = "ws"
+	}
+	u := url.URL{
+		Scheme: scheme,
+		Host:   hostPort(s.host, creds.Port),
+		Path:   "/",
+	}
+
+	dialer := websocket.Dialer{
+		TLSClientConfig:  s.tlsConfig,
+		Subprotocols:     []string{"wamp"},
+		HandshakeTimeout: 10 * time.Second,
+	}
+
+	conn, resp, err := dialer.Dial(u.String(), header)
+	if err != nil {
+		fmt.Printf("[ERROR] WebSocket连接失败: %v\n", err)
+		if resp != nil {
+			fmt.Printf("[ERROR] WebSocket响应状态: %s\n", resp.Status)
+			body, _ := io.ReadAll(resp.Body)
+			fmt.Printf("[ERROR] WebSocket响应体: %s\n", string(body))
+		}
+		return err
+	}
+
+	// 设置读取超时
+	conn.SetReadDeadline(time.Time{}) // 无限期等待
+
+	s.mu.Lock()
+	s.conn = conn
+	s.mu.Unlock()
+	return nil
+}
+
+// Subscribe 订阅事件，LCU WebSocket使用WAMP 1.0协议，5为订阅操作码
+func (s *websocketStream) Subscribe(event string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return fmt.Errorf("websocket not connected")
+	}
+	return s.conn.WriteJSON([]interface{}{5, event})
+}
+
+// Read 读取下一条消息
+func (s *websocketStream) Read() (json.RawMessage, error) {
+	s.mu.Lock()
+	conn := s.conn
+	s.mu.Unlock()
+
+	if conn == nil {
+		return nil, fmt.Errorf("websocket closed")
+	}
+
+	var msg json.RawMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// Close 关闭WebSocket连接
+func (s *websocketStream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
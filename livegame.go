@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// liveClientPort 游戏内Live Client Data API的固定端口
+	liveClientPort = 2999
+	// liveGamePollInterval 游戏内数据的轮询间隔
+	liveGamePollInterval = 2 * time.Second
+)
+
+// LiveGameEvent 游戏内事件，字段名与Live Client Data API保持一致
+type LiveGameEvent struct {
+	EventID    int      `json:"EventID"`
+	EventName  string   `json:"EventName"`
+	EventTime  float64  `json:"EventTime"`
+	KillerName string   `json:"KillerName,omitempty"`
+	VictimName string   `json:"VictimName,omitempty"`
+	Assisters  []string `json:"Assisters,omitempty"`
+	DragonType string   `json:"DragonType,omitempty"`
+	Stolen     string   `json:"Stolen,omitempty"`
+	Result     string   `json:"Result,omitempty"`
+}
+
+// LiveGameSnapshot 当前玩家的游戏内数据
+type LiveGameSnapshot struct {
+	GameID       int64           `json:"game_id,omitempty"`
+	StartedAt    time.Time       `json:"started_at"`
+	GameTime     float64         `json:"game_time"` // 游戏内时间，单位秒
+	GameMode     string          `json:"game_mode"`
+	ChampionName string          `json:"champion_name"`
+	Team         string          `json:"team"`
+	Level        int             `json:"level"`
+	CurrentGold  float64         `json:"current_gold"`
+	Kills        int             `json:"kills"`
+	Deaths       int             `json:"deaths"`
+	Assists      int             `json:"assists"`
+	CreepScore   int             `json:"creep_score"`
+	WardScore    float64         `json:"ward_score"`
+	Events       []LiveGameEvent `json:"events"`
+}
+
+// liveActivePlayer /liveclientdata/activeplayer 的响应
+type liveActivePlayer struct {
+	RiotID       string  `json:"riotId"`
+	SummonerName string  `json:"summonerName"`
+	Level        int     `json:"level"`
+	CurrentGold  float64 `json:"currentGold"`
+}
+
+// livePlayer /liveclientdata/allgamedata 中的玩家信息
+type livePlayer struct {
+	RiotID       string `json:"riotId"`
+	SummonerName string `json:"summonerName"`
+	ChampionName string `json:"championName"`
+	Team         string `json:"team"`
+	Scores       struct {
+		Kills      int     `json:"kills"`
+		Deaths     int     `json:"deaths"`
+		Assists    int     `json:"assists"`
+		CreepScore int     `json:"creepScore"`
+		WardScore  float64 `json:"wardScore"`
+	} `json:"scores"`
+}
+
+// liveAllGameData /liveclientdata/allgamedata 的响应，事件通过eventdata单独增量获取
+type liveAllGameData struct {
+	ActivePlayer liveActivePlayer `json:"activePlayer"`
+	AllPlayers   []livePlayer     `json:"allPlayers"`
+	GameData     struct {
+		GameMode string  `json:"gameMode"`
+		GameTime float64 `json:"gameTime"`
+	} `json:"gameData"`
+}
+
+// LiveClient 游戏内Live Client Data API客户端，该接口不需要认证
+type LiveClient struct {
+	transport RESTTransport
+	creds     *LCUCredentials
+}
+
+// NewLiveClient 创建游戏内数据客户端
+func NewLiveClient(transport RESTTransport, port int) *LiveClient {
+	return &LiveClient{
+		transport: transport,
+		creds:     &LCUCredentials{Port: port, Protocol: "https"},
+	}
+}
+
+// get 请求游戏内数据接口并解析响应
+func (c *LiveClient) get(path string, out interface{}) error {
+	status, body, err := c.transport.Do(c.creds, "GET", path, nil)
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("HTTP %d: %s", status, string(body))
+	}
+	return json.Unmarshal(body, out)
+}
+
+// GetAllGameData 获取全部游戏数据
+func (c *LiveClient) GetAllGameData() (*liveAllGameData, error) {
+	data := &liveAllGameData{}
+	if err := c.get("/liveclientdata/allgamedata", data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// GetActivePlayer 获取当前玩家数据
+func (c *LiveClient) GetActivePlayer() (*liveActivePlayer, error) {
+	player := &liveActivePlayer{}
+	if err := c.get("/liveclientdata/activeplayer", player); err != nil {
+		return nil, err
+	}
+	return player, nil
+}
+
+// GetEventData 获取从指定事件ID开始的游戏事件
+func (c *LiveClient) GetEventData(fromEventID int) ([]LiveGameEvent, error) {
+	var result struct {
+		Events []LiveGameEvent `json:"Events"`
+	}
+	if err := c.get(fmt.Sprintf("/liveclientdata/eventdata?eventID=%d", fromEventID), &result); err != nil {
+		return nil, err
+	}
+	return result.Events, nil
+}
+
+// LiveGamePoller 在对局进行中定期轮询游戏内数据
+type LiveGamePoller struct {
+	client   *LiveClient
+	interval time.Duration
+
+	// 回调在轮询协程中执行
+	onUpdate func(*LiveGameSnapshot)
+	onEvent  func(LiveGameEvent)
+	onFinish func(*LiveGameSnapshot)
+
+	snapshot *LiveGameSnapshot
+	stopChan chan struct{}
+	mu       sync.RWMutex
+}
+
+// LiveGamePollerOption 游戏内数据轮询器的构造选项
+type LiveGamePollerOption func(*LiveGamePoller)
+
+// WithPollInterval 使用指定的轮询间隔
+func WithPollInterval(interval time.Duration) LiveGamePollerOption {
+	return func(p *LiveGamePoller) {
+		p.interval = interval
+	}
+}
+
+// NewLiveGamePoller 创建游戏内数据轮询器
+func NewLiveGamePoller(client *LiveClient, opts ...LiveGamePollerOption) *LiveGamePoller {
+	poller := &LiveGamePoller{
+		client:   client,
+		interval: liveGamePollInterval,
+	}
+	for _, opt := range opts {
+		opt(poller)
+	}
+	return poller
+}
+
+// Start 开始轮询，已在轮询时不做任何操作
+func (p *LiveGamePoller) Start(gameID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopChan != nil {
+		return
+	}
+
+	snapshot := &LiveGameSnapshot{GameID: gameID, StartedAt: time.Now(), Events: []LiveGameEvent{}}
+	stopChan := make(chan struct{})
+	p.snapshot = snapshot
+	p.stopChan = stopChan
+
+	fmt.Printf("[INFO] Live game polling started for game %d\n", gameID)
+	go p.run(snapshot, stopChan)
+}
+
+// Stop 停止轮询，最后一次数据通过onFinish回调返回
+func (p *LiveGamePoller) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.stopChan == nil {
+		return
+	}
+	close(p.stopChan)
+	p.stopChan = nil
+	p.snapshot = nil
+}
+
+// Snapshot 获取当前对局数据的副本，未在对局中时返回nil
+func (p *LiveGamePoller) Snapshot() *LiveGameSnapshot {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.snapshot == nil {
+		return nil
+	}
+	snapshot := *p.snapshot
+	snapshot.Events = append([]LiveGameEvent(nil), p.snapshot.Events...)
+	return &snapshot
+}
+
+// run 轮询循环
+func (p *LiveGamePoller) run(snapshot *LiveGameSnapshot, stopChan chan struct{}) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			fmt.Printf("[INFO] Live game polling stopped for game %d\n", snapshot.GameID)
+			if p.onFinish != nil {
+				p.mu.RLock()
+				final := *snapshot
+				p.mu.RUnlock()
+				p.onFinish(&final)
+			}
+			return
+		case <-ticker.C:
+			p.poll(snapshot)
+		}
+	}
+}
+
+// poll 获取一次游戏内数据，游戏加载期间接口不可用时直接跳过
+func (p *LiveGamePoller) poll(snapshot *LiveGameSnapshot) {
+	active, err := p.client.GetActivePlayer()
+	if err != nil {
+		return
+	}
+	game, err := p.client.GetAllGameData()
+	if err != nil {
+		return
+	}
+
+	p.mu.RLock()
+	nextEventID := 0
+	if n := len(snapshot.Events); n > 0 {
+		nextEventID = snapshot.Events[n-1].EventID + 1
+	}
+	p.mu.RUnlock()
+
+	events, err := p.client.GetEventData(nextEventID)
+	if err != nil {
+		events = nil
+	}
+
+	p.mu.Lock()
+	snapshot.GameTime = game.GameData.GameTime
+	snapshot.GameMode = game.GameData.GameMode
+	snapshot.Level = active.Level
+	snapshot.CurrentGold = active.CurrentGold
+	for _, player := range game.AllPlayers {
+		if !isActivePlayer(player, active) {
+			continue
+		}
+		snapshot.ChampionName = player.ChampionName
+		snapshot.Team = player.Team
+		snapshot.Kills = player.Scores.Kills
+		snapshot.Deaths = player.Scores.Deaths
+		snapshot.Assists = player.Scores.Assists
+		snapshot.CreepScore = player.Scores.CreepScore
+		snapshot.WardScore = player.Scores.WardScore
+		break
+	}
+	var newEvents []LiveGameEvent
+	for _, event := range events {
+		if event.EventID < nextEventID {
+			continue
+		}
+		snapshot.Events = append(snapshot.Events, event)
+		newEvents = append(newEvents, event)
+	}
+	update := *snapshot
+	update.Events = append([]LiveGameEvent(nil), snapshot.Events...)
+	p.mu.Unlock()
+
+	if p.onEvent != nil {
+		for _, event := range newEvents {
+			p.onEvent(event)
+		}
+	}
+	if p.onUpdate != nil {
+		p.onUpdate(&update)
+	}
+}
+
+// isActivePlayer 判断玩家是否为当前玩家，新版本使用riotId，旧版本使用summonerName
+func isActivePlayer(player livePlayer, active *liveActivePlayer) bool {
+	if active.RiotID != "" {
+		return player.RiotID == active.RiotID
+	}
+	return player.SummonerName == active.SummonerName
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeLiveClientServer 模拟游戏内Live Client Data API，每次请求allgamedata都会推进游戏时间并产生一个新事件
+type fakeLiveClientServer struct {
+	mu       sync.Mutex
+	gameTime float64
+	events   []LiveGameEvent
+	eventIDs []int // eventdata请求的起始事件ID
+}
+
+func (s *fakeLiveClientServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var response interface{}
+	switch r.URL.Path {
+	case "/liveclientdata/activeplayer":
+		response = map[string]interface{}{"riotId": "Tester#CN1", "level": 6, "currentGold": 1250.5}
+	case "/liveclientdata/allgamedata":
+		s.gameTime += 30
+		s.events = append(s.events, LiveGameEvent{EventID: len(s.events), EventName: "ChampionKill", EventTime: s.gameTime, KillerName: "Tester"})
+		response = map[string]interface{}{
+			"gameData": map[string]interface{}{"gameMode": "CLASSIC", "gameTime": s.gameTime},
+			"allPlayers": []map[string]interface{}{
+				{"riotId": "Other#CN1", "championName": "Yasuo", "team": "CHAOS"},
+				{"riotId": "Tester#CN1", "championName": "Ahri", "team": "ORDER", "scores": map[string]interface{}{"kills": len(s.events), "deaths": 1, "assists": 2, "creepScore": 40}},
+			},
+		}
+	case "/liveclientdata/eventdata":
+		from, err := strconv.Atoi(r.URL.Query().Get("eventID"))
+		if err != nil {
+			http.Error(w, "missing eventID", http.StatusBadRequest)
+			return
+		}
+		s.eventIDs = append(s.eventIDs, from)
+		var events []LiveGameEvent
+		for _, event := range s.events {
+			if event.EventID >= from {
+				events = append(events, event)
+			}
+		}
+		response = map[string]interface{}{"Events": events}
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	json.NewEncoder(w).Encode(response)
+}
+
+func newTestLiveClient(t *testing.T, handler http.Handler) *LiveClient {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port, err := strconv.Atoi(u.Port())
+	if err != nil {
+		t.Fatal(err)
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+	transport := NewHTTPTransport(defaultLCUHost, newPinnedTLSConfig(defaultLCUHost, roots))
+	return NewLiveClient(transport, port)
+}
+
+func TestLiveClient(t *testing.T) {
+	client := newTestLiveClient(t, &fakeLiveClientServer{})
+
+	active, err := client.GetActivePlayer()
+	if err != nil {
+		t.Fatalf("GetActivePlayer() error = %v", err)
+	}
+	if active.RiotID != "Tester#CN1" || active.Level != 6 {
+		t.Errorf("GetActivePlayer() = %+v", active)
+	}
+
+	game, err := client.GetAllGameData()
+	if err != nil {
+		t.Fatalf("GetAllGameData() error = %v", err)
+	}
+	if game.GameData.GameMode != "CLASSIC" || len(game.AllPlayers) != 2 {
+		t.Errorf("GetAllGameData() = %+v", game)
+	}
+
+	events, err := client.GetEventData(1)
+	if err != nil {
+		t.Fatalf("GetEventData() error = %v", err)
+	}
+	if len(events) != 0 {
+		t.Errorf("GetEventData(1) = %+v, want no events", events)
+	}
+}
+
+func TestLiveGamePoller(t *testing.T) {
+	server := &fakeLiveClientServer{}
+	poller := NewLiveGamePoller(newTestLiveClient(t, server), WithPollInterval(5*time.Millisecond))
+
+	updates := make(chan *LiveGameSnapshot, 100)
+	finished := make(chan *LiveGameSnapshot, 1)
+	var eventsMu sync.Mutex
+	var events []LiveGameEvent
+	poller.onUpdate = func(snapshot *LiveGameSnapshot) { updates <- snapshot }
+	poller.onEvent = func(event LiveGameEvent) {
+		eventsMu.Lock()
+		events = append(events, event)
+		eventsMu.Unlock()
+	}
+	poller.onFinish = func(snapshot *LiveGameSnapshot) { finished <- snapshot }
+
+	if poller.Snapshot() != nil {
+		t.Fatal("Snapshot() before Start should be nil")
+	}
+	poller.Start(42)
+	poller.Start(42) // 重复调用不应启动第二个轮询
+
+	timeout := time.After(5 * time.Second)
+	for done := false; !done; {
+		select {
+		case snapshot := <-updates:
+			done = len(snapshot.Events) >= 3
+		case <-timeout:
+			t.Fatal("timed out waiting for live game updates")
+		}
+	}
+
+	snapshot := poller.Snapshot()
+	if snapshot == nil || snapshot.GameID != 42 || snapshot.ChampionName != "Ahri" || snapshot.Team != "ORDER" || snapshot.Level != 6 {
+		t.Errorf("Snapshot() = %+v", snapshot)
+	}
+
+	poller.Stop()
+	var final *LiveGameSnapshot
+	select {
+	case final = <-finished:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for onFinish")
+	}
+	if poller.Snapshot() != nil {
+		t.Error("Snapshot() after Stop should be nil")
+	}
+
+	// 事件按ID递增且不重复
+	for i, event := range final.Events {
+		if event.EventID != i {
+			t.Fatalf("final events = %+v, want consecutive IDs from 0", final.Events)
+		}
+	}
+	if final.Kills != len(final.Events) {
+		t.Errorf("final kills = %d, events = %d", final.Kills, len(final.Events))
+	}
+	eventsMu.Lock()
+	if len(events) != len(final.Events) {
+		t.Errorf("onEvent called %d times, want %d", len(events), len(final.Events))
+	}
+	eventsMu.Unlock()
+
+	// eventdata按上一次收到的最后一个事件增量请求
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	for i, from := range server.eventIDs {
+		if from != i {
+			t.Fatalf("eventdata requested from %v, want 0, 1, 2, ...", server.eventIDs)
+		}
+	}
+}
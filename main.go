@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"os"
 
 	"github.com/wailsapp/wails/v2"
 	"github.com/wailsapp/wails/v2/pkg/options"
@@ -13,9 +14,20 @@ import (
 var assets embed.FS
 
 func main() {
+	// 回放录制的LCU会话，不启动界面
+	if path := flagValue(os.Args[1:], replayFlag); path != "" {
+		os.Exit(runReplay(path))
+	}
+
 	// Create an instance of the app structure
 	app := NewApp()
 
+	// 便携模式下WebView2的数据也保存在可执行文件旁
+	webviewDataPath := ""
+	if IsPortableMode() {
+		webviewDataPath, _ = GetDataPath("webview")
+	}
+
 	// Create application with options
 	err := wails.Run(&options.App{
 		Title:           "AutoBP",
@@ -40,6 +52,7 @@ func main() {
 		Windows: &windows.Options{
 			ZoomFactor:           1.25,
 			IsZoomControlEnabled: false,
+			WebviewUserDataPath:  webviewDataPath,
 		},
 		Bind: []interface{}{
 			app,
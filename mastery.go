@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ChampionMastery 英雄成就
+type ChampionMastery struct {
+	ChampionID     int `json:"championId"`
+	ChampionLevel  int `json:"championLevel"`
+	ChampionPoints int `json:"championPoints"`
+}
+
+// fetchChampionMastery 获取召唤师所有英雄的成就
+func fetchChampionMastery(lcu jsonRequester, summonerID int64) ([]ChampionMastery, error) {
+	var masteries []ChampionMastery
+	path := fmt.Sprintf("/lol-collections/v1/inventories/%d/champion-mastery", summonerID)
+	if err := lcu.RequestJSON("GET", path, nil, &masteries); err != nil {
+		return nil, err
+	}
+	if masteries == nil {
+		masteries = []ChampionMastery{}
+	}
+	return masteries, nil
+}
+
+// MasteryService 当前召唤师的英雄成就
+type MasteryService struct {
+	masteries map[int]ChampionMastery
+	mu        sync.RWMutex
+}
+
+// NewMasteryService 创建空的英雄成就服务
+func NewMasteryService() *MasteryService {
+	return &MasteryService{masteries: make(map[int]ChampionMastery)}
+}
+
+// Load 从LCU获取当前召唤师的英雄成就
+func (s *MasteryService) Load(lcu jsonRequester) error {
+	var summoner struct {
+		SummonerID int64 `json:"summonerId"`
+	}
+	if err := lcu.RequestJSON("GET", "/lol-summoner/v1/current-summoner", nil, &summoner); err != nil {
+		return fmt.Errorf("failed to get current summoner: %w", err)
+	}
+	if summoner.SummonerID == 0 {
+		return fmt.Errorf("current summoner has no summoner id")
+	}
+
+	masteries, err := fetchChampionMastery(lcu, summoner.SummonerID)
+	if err != nil {
+		return fmt.Errorf("failed to get champion mastery: %w", err)
+	}
+
+	byChampion := make(map[int]ChampionMastery, len(masteries))
+	for _, mastery := range masteries {
+		byChampion[mastery.ChampionID] = mastery
+	}
+
+	s.mu.Lock()
+	s.masteries = byChampion
+	s.mu.Unlock()
+	return nil
+}
+
+// Get 获取英雄的成就
+func (s *MasteryService) Get(championID int) (ChampionMastery, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	mastery, ok := s.masteries[championID]
+	return mastery, ok
+}
+
+// points 获取英雄的成就点数，没有数据时为0
+func (s *MasteryService) points(championID int) int {
+	mastery, _ := s.Get(championID)
+	return mastery.ChampionPoints
+}
+
+// SortByMastery 按成就点数从高到低排列英雄，点数相同时保持原有顺序
+func (s *MasteryService) SortByMastery(championIDs []int) []int {
+	sorted := append([]int(nil), championIDs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return s.points(sorted[i]) > s.points(sorted[j])
+	})
+	return sorted
+}
+
+// Ranked 获取所有有成就点数的英雄，按点数从高到低排列
+func (s *MasteryService) Ranked() []int {
+	s.mu.RLock()
+	championIDs := make([]int, 0, len(s.masteries))
+	for championID, mastery := range s.masteries {
+		if mastery.ChampionPoints > 0 {
+			championIDs = append(championIDs, championID)
+		}
+	}
+	s.mu.RUnlock()
+
+	sort.Ints(championIDs)
+	return s.SortByMastery(championIDs)
+}
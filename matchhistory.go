@@ -0,0 +1,439 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const (
+	// matchHistoryPageSize 每次从LCU获取的对局数，LCU单次最多返回20局
+	matchHistoryPageSize = 20
+	// maxCachedMatches 每个玩家本地缓存的最大对局数
+	maxCachedMatches = 500
+	// recentFormGames 近期状态统计的对局数
+	recentFormGames = 5
+)
+
+// jsonRequester 能够请求LCU接口并解析响应的对象
+type jsonRequester interface {
+	RequestJSON(method, path string, body interface{}, out interface{}) error
+}
+
+// lcuMatchHistory /lol-match-history/v1/products/lol/{puuid}/matches 的响应
+type lcuMatchHistory struct {
+	Games struct {
+		Games []lcuMatch `json:"games"`
+	} `json:"games"`
+}
+
+// lcuMatch 战绩中的一局游戏
+type lcuMatch struct {
+	GameID                int64  `json:"gameId"`
+	GameCreation          int64  `json:"gameCreation"` // 毫秒时间戳
+	GameDuration          int    `json:"gameDuration"` // 单位秒
+	GameMode              string `json:"gameMode"`
+	QueueID               int    `json:"queueId"`
+	ParticipantIdentities []struct {
+		ParticipantID int `json:"participantId"`
+		Player        struct {
+			PUUID string `json:"puuid"`
+		} `json:"player"`
+	} `json:"participantIdentities"`
+	Participants []struct {
+		ParticipantID int `json:"participantId"`
+		ChampionID    int `json:"championId"`
+		Timeline      struct {
+			Lane string `json:"lane"`
+			Role string `json:"role"`
+		} `json:"timeline"`
+		Stats struct {
+			Win                  bool `json:"win"`
+			Kills                int  `json:"kills"`
+			Deaths               int  `json:"deaths"`
+			Assists              int  `json:"assists"`
+			TotalMinionsKilled   int  `json:"totalMinionsKilled"`
+			NeutralMinionsKilled int  `json:"neutralMinionsKilled"`
+		} `json:"stats"`
+	} `json:"participants"`
+}
+
+// MatchRecord 战绩中一名玩家的一局游戏
+type MatchRecord struct {
+	GameID       int64  `json:"game_id"`
+	GameCreation int64  `json:"game_creation"` // 毫秒时间戳
+	GameDuration int    `json:"game_duration"` // 单位秒
+	GameMode     string `json:"game_mode"`
+	QueueID      int    `json:"queue_id"`
+	ChampionID   int    `json:"champion_id"`
+	Position     string `json:"position"` // TOP, JUNGLE, MIDDLE, BOTTOM, UTILITY，无法判断时为空
+	Win          bool   `json:"win"`
+	Kills        int    `json:"kills"`
+	Deaths       int    `json:"deaths"`
+	Assists      int    `json:"assists"`
+	CreepScore   int    `json:"creep_score"`
+}
+
+// matchPosition 将战绩中的分路和角色转换为选人阶段使用的位置
+func matchPosition(lane, role string) string {
+	switch strings.ToUpper(lane) {
+	case "TOP":
+		return "TOP"
+	case "JUNGLE":
+		return "JUNGLE"
+	case "MIDDLE", "MID":
+		return "MIDDLE"
+	case "BOTTOM", "BOT":
+		switch strings.ToUpper(role) {
+		case "DUO_SUPPORT", "SUPPORT":
+			return "UTILITY"
+		}
+		return "BOTTOM"
+	}
+	return ""
+}
+
+// normalizePosition 将前端或选人阶段传入的位置转换为战绩中使用的位置，如 "jungle" -> "JUNGLE"、"support" -> "UTILITY"
+func normalizePosition(position string) string {
+	switch position = strings.ToUpper(strings.TrimSpace(position)); position {
+	case "SUPPORT":
+		return "UTILITY"
+	case "MID":
+		return "MIDDLE"
+	case "BOT":
+		return "BOTTOM"
+	}
+	return position
+}
+
+// fetchMatchHistory 获取指定玩家从begIndex开始的一页战绩，最新的在前
+func fetchMatchHistory(lcu jsonRequester, puuid string, begIndex int) ([]MatchRecord, error) {
+	path := fmt.Sprintf("/lol-match-history/v1/products/lol/%s/matches?begIndex=%d&endIndex=%d",
+		puuid, begIndex, begIndex+matchHistoryPageSize-1)
+
+	var history lcuMatchHistory
+	if err := lcu.RequestJSON("GET", path, nil, &history); err != nil {
+		return nil, err
+	}
+
+	records := make([]MatchRecord, 0, len(history.Games.Games))
+	for _, match := range history.Games.Games {
+		if record, ok := newMatchRecord(match, puuid); ok {
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+// newMatchRecord 从一局游戏中提取指定玩家的数据
+func newMatchRecord(match lcuMatch, puuid string) (MatchRecord, bool) {
+	participantID := 0
+	for _, identity := range match.ParticipantIdentities {
+		if identity.Player.PUUID == puuid {
+			participantID = identity.ParticipantID
+			break
+		}
+	}
+	// 个人战绩列表只包含玩家自己
+	if participantID == 0 && len(match.Participants) == 1 {
+		participantID = match.Participants[0].ParticipantID
+	}
+
+	for _, participant := range match.Participants {
+		if participant.ParticipantID != participantID {
+			continue
+		}
+		// 只有召唤师峡谷的对局有分路
+		position := ""
+		if match.GameMode == "CLASSIC" {
+			position = matchPosition(participant.Timeline.Lane, participant.Timeline.Role)
+		}
+		return MatchRecord{
+			GameID:       match.GameID,
+			GameCreation: match.GameCreation,
+			GameDuration: match.GameDuration,
+			GameMode:     match.GameMode,
+			QueueID:      match.QueueID,
+			ChampionID:   participant.ChampionID,
+			Position:     position,
+			Win:          participant.Stats.Win,
+			Kills:        participant.Stats.Kills,
+			Deaths:       participant.Stats.Deaths,
+			Assists:      participant.Stats.Assists,
+			CreepScore:   participant.Stats.TotalMinionsKilled + participant.Stats.NeutralMinionsKilled,
+		}, true
+	}
+	return MatchRecord{}, false
+}
+
+// MatchHistoryCache 按玩家缓存的战绩，每个玩家的对局按时间从新到旧排列
+type MatchHistoryCache struct {
+	Matches   map[string][]MatchRecord `json:"matches"`    // 键为puuid
+	LastPUUID string                   `json:"last_puuid"` // 最近一次查询的当前玩家
+	path      string                   // 为空时只保存在内存中
+	mu        sync.RWMutex
+	saveMu    sync.Mutex // 保证文件按保存顺序写入
+}
+
+// GetMatchHistoryPath 获取战绩缓存文件的完整路径
+func GetMatchHistoryPath() (string, error) {
+	return GetDataPath("match_history.json")
+}
+
+// NewMatchHistoryCache 创建只保存在内存中的战绩缓存
+func NewMatchHistoryCache() *MatchHistoryCache {
+	return &MatchHistoryCache{Matches: make(map[string][]MatchRecord)}
+}
+
+// LoadMatchHistoryCache 从文件加载战绩缓存
+func LoadMatchHistoryCache() (*MatchHistoryCache, error) {
+	filename, err := GetMatchHistoryPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get match history path: %w", err)
+	}
+
+	cache := NewMatchHistoryCache()
+	cache.path = filename
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cache, nil
+		}
+		return nil, fmt.Errorf("failed to read match history file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, cache); err != nil {
+		return nil, fmt.Errorf("failed to parse match history file: %w", err)
+	}
+	if cache.Matches == nil {
+		cache.Matches = make(map[string][]MatchRecord)
+	}
+
+	return cache, nil
+}
+
+// Save 保存战绩缓存到文件
+func (c *MatchHistoryCache) Save() error {
+	if c.path == "" {
+		return nil
+	}
+
+	c.saveMu.Lock()
+	defer c.saveMu.Unlock()
+
+	c.mu.RLock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	c.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal match history: %w", err)
+	}
+
+	if err := writeFileAtomic(c.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write match history file: %w", err)
+	}
+
+	return nil
+}
+
+// Merge 将新获取的对局合并到缓存中，按游戏ID去重
+func (c *MatchHistoryCache) Merge(puuid string, records []MatchRecord) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	byID := make(map[int64]MatchRecord, len(c.Matches[puuid])+len(records))
+	for _, record := range c.Matches[puuid] {
+		byID[record.GameID] = record
+	}
+	for _, record := range records {
+		byID[record.GameID] = record
+	}
+
+	merged := make([]MatchRecord, 0, len(byID))
+	for _, record := range byID {
+		merged = append(merged, record)
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].GameCreation > merged[j].GameCreation
+	})
+	if len(merged) > maxCachedMatches {
+		merged = merged[:maxCachedMatches]
+	}
+	c.Matches[puuid] = merged
+}
+
+// Get 获取玩家缓存的全部对局
+func (c *MatchHistoryCache) Get(puuid string) []MatchRecord {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]MatchRecord(nil), c.Matches[puuid]...)
+}
+
+// SetLastPUUID 记录当前玩家
+func (c *MatchHistoryCache) SetLastPUUID(puuid string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.LastPUUID = puuid
+}
+
+// GetLastPUUID 获取最近一次查询的当前玩家
+func (c *MatchHistoryCache) GetLastPUUID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.LastPUUID
+}
+
+// Performance 一组对局的表现统计
+type Performance struct {
+	Games      int     `json:"games"`
+	Wins       int     `json:"wins"`
+	WinRate    float64 `json:"win_rate"` // 0到1
+	KDA        float64 `json:"kda"`
+	RecentForm string  `json:"recent_form"` // 最近几局的胜负，最新的在前，如 "WWLWL"
+}
+
+// ChampionPerformance 单个英雄的表现统计
+type ChampionPerformance struct {
+	ChampionID int `json:"champion_id"`
+	Performance
+}
+
+// PositionPerformance 单个位置的表现统计
+type PositionPerformance struct {
+	Position string `json:"position"`
+	Performance
+}
+
+// computePerformance 统计一组按时间从新到旧排列的对局
+func computePerformance(records []MatchRecord) Performance {
+	var perf Performance
+	var kills, deaths, assists int
+	var form strings.Builder
+	for _, record := range records {
+		perf.Games++
+		if record.Win {
+			perf.Wins++
+		}
+		kills += record.Kills
+		deaths += record.Deaths
+		assists += record.Assists
+		if form.Len() < recentFormGames {
+			if record.Win {
+				form.WriteByte('W')
+			} else {
+				form.WriteByte('L')
+			}
+		}
+	}
+
+	if perf.Games > 0 {
+		perf.WinRate = float64(perf.Wins) / float64(perf.Games)
+	}
+	if deaths == 0 {
+		deaths = 1
+	}
+	perf.KDA = float64(kills+assists) / float64(deaths)
+	perf.RecentForm = form.String()
+	return perf
+}
+
+// ChampionPerformances 按英雄统计表现，position为空时统计所有位置，不区分大小写，按场次从多到少排列
+func ChampionPerformances(records []MatchRecord, position string) []ChampionPerformance {
+	position = normalizePosition(position)
+	byChampion := make(map[int][]MatchRecord)
+	for _, record := range records {
+		if position != "" && record.Position != position {
+			continue
+		}
+		byChampion[record.ChampionID] = append(byChampion[record.ChampionID], record)
+	}
+
+	result := make([]ChampionPerformance, 0, len(byChampion))
+	for championID, championRecords := range byChampion {
+		result = append(result, ChampionPerformance{
+			ChampionID:  championID,
+			Performance: computePerformance(championRecords),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Games != result[j].Games {
+			return result[i].Games > result[j].Games
+		}
+		return result[i].ChampionID < result[j].ChampionID
+	})
+	return result
+}
+
+// PositionPerformances 按位置统计表现，按场次从多到少排列
+func PositionPerformances(records []MatchRecord) []PositionPerformance {
+	byPosition := make(map[string][]MatchRecord)
+	for _, record := range records {
+		if record.Position == "" {
+			continue
+		}
+		byPosition[record.Position] = append(byPosition[record.Position], record)
+	}
+
+	result := make([]PositionPerformance, 0, len(byPosition))
+	for position, positionRecords := range byPosition {
+		result = append(result, PositionPerformance{
+			Position:    position,
+			Performance: computePerformance(positionRecords),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Games != result[j].Games {
+			return result[i].Games > result[j].Games
+		}
+		return result[i].Position < result[j].Position
+	})
+	return result
+}
+
+// TopChampionsByWinRate 返回指定位置近期胜率最高的英雄，至少需要minGames场
+func TopChampionsByWinRate(records []MatchRecord, position string, minGames, n int) []ChampionPerformance {
+	var top []ChampionPerformance
+	for _, perf := range ChampionPerformances(records, position) {
+		if perf.Games >= minGames {
+			top = append(top, perf)
+		}
+	}
+	sort.SliceStable(top, func(i, j int) bool {
+		if top[i].WinRate != top[j].WinRate {
+			return top[i].WinRate > top[j].WinRate
+		}
+		return top[i].KDA > top[j].KDA
+	})
+	if n > 0 && len(top) > n {
+		top = top[:n]
+	}
+	return top
+}
+
+// SortByWinRate 按指定位置的近期胜率从高到低排列英雄，胜率相同时按KDA排列
+// 场次不足minGames的英雄排在后面并保持原有顺序
+func SortByWinRate(records []MatchRecord, position string, minGames int, championIDs []int) []int {
+	perfs := make(map[int]ChampionPerformance)
+	for _, perf := range ChampionPerformances(records, position) {
+		if perf.Games >= minGames {
+			perfs[perf.ChampionID] = perf
+		}
+	}
+
+	sorted := append([]int(nil), championIDs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, aOK := perfs[sorted[i]]
+		b, bOK := perfs[sorted[j]]
+		if aOK != bOK {
+			return aOK
+		}
+		if a.WinRate != b.WinRate {
+			return a.WinRate > b.WinRate
+		}
+		return a.KDA > b.KDA
+	})
+	return sorted
+}
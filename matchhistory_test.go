@@ -0,0 +1,124 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestMatchPosition(t *testing.T) {
+	tests := []struct {
+		lane, role string
+		want       string
+	}{
+		{"TOP", "SOLO", "TOP"},
+		{"JUNGLE", "NONE", "JUNGLE"},
+		{"MID", "SOLO", "MIDDLE"},
+		{"BOTTOM", "DUO_CARRY", "BOTTOM"},
+		{"BOTTOM", "CARRY", "BOTTOM"},
+		{"BOTTOM", "DUO_SUPPORT", "UTILITY"},
+		{"BOTTOM", "SUPPORT", "UTILITY"},
+		{"bot", "support", "UTILITY"},
+		{"NONE", "DUO", ""},
+	}
+
+	for _, tt := range tests {
+		if got := matchPosition(tt.lane, tt.role); got != tt.want {
+			t.Errorf("matchPosition(%q, %q) = %q, want %q", tt.lane, tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestSortByWinRate(t *testing.T) {
+	records := []MatchRecord{
+		{ChampionID: 103, Position: "MIDDLE", Win: false},
+		{ChampionID: 103, Position: "MIDDLE", Win: true},
+		{ChampionID: 157, Position: "MIDDLE", Win: true},
+		{ChampionID: 157, Position: "MIDDLE", Win: true},
+		{ChampionID: 238, Position: "MIDDLE", Win: true, Kills: 10},
+		{ChampionID: 238, Position: "MIDDLE", Win: false},
+		// 其他位置的对局不参与统计
+		{ChampionID: 1, Position: "TOP", Win: true},
+		{ChampionID: 1, Position: "TOP", Win: true},
+		// 场次不足
+		{ChampionID: 99, Position: "MIDDLE", Win: true},
+	}
+
+	got := SortByWinRate(records, "MIDDLE", 2, []int{1, 99, 103, 238, 157})
+	want := []int{157, 238, 103, 1, 99}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortByWinRate() = %v, want %v", got, want)
+	}
+}
+
+func TestChampionPerformancesNormalizesPosition(t *testing.T) {
+	records := []MatchRecord{
+		{ChampionID: 64, Position: "JUNGLE", Win: true},
+		{ChampionID: 64, Position: "JUNGLE", Win: true},
+		{ChampionID: 11, Position: "JUNGLE", Win: false},
+		{ChampionID: 11, Position: "JUNGLE", Win: true},
+		{ChampionID: 412, Position: "UTILITY", Win: true},
+		{ChampionID: 412, Position: "UTILITY", Win: false},
+	}
+
+	top := TopChampionsByWinRate(records, "jungle", 2, 3)
+	if len(top) != 2 || top[0].ChampionID != 64 || top[1].ChampionID != 11 {
+		t.Errorf("TopChampionsByWinRate(jungle) = %+v, want 64 then 11", top)
+	}
+
+	support := ChampionPerformances(records, "support")
+	if len(support) != 1 || support[0].ChampionID != 412 {
+		t.Errorf("ChampionPerformances(support) = %+v, want 412", support)
+	}
+
+	if got := SortByWinRate(records, "Jungle", 2, []int{11, 64}); !reflect.DeepEqual(got, []int{64, 11}) {
+		t.Errorf("SortByWinRate(Jungle) = %v, want [64 11]", got)
+	}
+}
+
+func TestMatchHistoryCacheConcurrentSave(t *testing.T) {
+	dir := t.TempDir()
+	cache := NewMatchHistoryCache()
+	cache.path = filepath.Join(dir, "match_history.json")
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 20; i++ {
+		wg.Add(1)
+		go func(gameID int64) {
+			defer wg.Done()
+			cache.Merge(fmt.Sprintf("puuid-%d", gameID%3), []MatchRecord{{GameID: gameID}})
+			if err := cache.Save(); err != nil {
+				t.Errorf("Save() error = %v", err)
+			}
+		}(int64(i))
+	}
+	wg.Wait()
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		t.Fatalf("failed to read match history file: %v", err)
+	}
+	saved := NewMatchHistoryCache()
+	if err := json.Unmarshal(data, saved); err != nil {
+		t.Fatalf("match history file is not valid JSON: %v", err)
+	}
+	total := 0
+	for _, records := range saved.Matches {
+		total += len(records)
+	}
+	if total != 20 {
+		t.Errorf("saved %d matches, want 20", total)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("data directory has %d entries, want only match_history.json", len(entries))
+	}
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// replayFlag 回放录制文件的命令行参数
+const replayFlag = "--replay"
+
+// RecordedFrame 录制文件中的一条WebSocket消息
+type RecordedFrame struct {
+	Time  time.Time       `json:"time"`
+	Frame json.RawMessage `json:"frame"`
+}
+
+// SessionRecorder 将LCU的WebSocket消息按JSONL格式写入文件
+type SessionRecorder struct {
+	path    string
+	file    *os.File
+	encoder *json.Encoder
+	mu      sync.Mutex
+}
+
+// NewSessionRecorder 在数据目录的recordings文件夹下创建新的录制文件
+func NewSessionRecorder() (*SessionRecorder, error) {
+	name := fmt.Sprintf("session-%s.jsonl", time.Now().Format("20060102-150405"))
+	path, err := GetDataPath("recordings", name)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SessionRecorder{
+		path:    path,
+		file:    file,
+		encoder: json.NewEncoder(file),
+	}, nil
+}
+
+// Path 录制文件路径
+func (r *SessionRecorder) Path() string {
+	return r.path
+}
+
+// Record 写入一条消息
+func (r *SessionRecorder) Record(frame json.RawMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return
+	}
+	if err := r.encoder.Encode(RecordedFrame{Time: time.Now(), Frame: frame}); err != nil {
+		fmt.Printf("[ERROR] Failed to record frame: %v\n", err)
+	}
+}
+
+// Close 关闭录制文件，可重复调用
+func (r *SessionRecorder) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file != nil {
+		r.file.Close()
+		r.file = nil
+	}
+}
+
+// LoadRecording 读取录制文件
+func LoadRecording(path string) ([]RecordedFrame, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var frames []RecordedFrame
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var frame RecordedFrame
+		if err := json.Unmarshal(scanner.Bytes(), &frame); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		frames = append(frames, frame)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return frames, nil
+}
+
+// ReplayRequest 回放时LCU连接器发出的请求
+type ReplayRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// ReplayAction 回放时对Ban/Pick操作的修改
+type ReplayAction struct {
+	ActionID   int  `json:"actionId"`
+	ChampionID int  `json:"championId"`
+	Completed  bool `json:"completed"`
+}
+
+// ReplayResult 回放结果
+type ReplayResult struct {
+	Frames   int             `json:"frames"`
+	Requests []ReplayRequest `json:"requests"`
+}
+
+var actionPathPattern = regexp.MustCompile(`^/lol-champ-select/v1/session/actions/(\d+)$`)
+
+// Actions 获取回放中对Ban/Pick操作的所有修改，按发出顺序排列
+func (r *ReplayResult) Actions() []ReplayAction {
+	var actions []ReplayAction
+	for _, request := range r.Requests {
+		match := actionPathPattern.FindStringSubmatch(request.Path)
+		if request.Method != "PATCH" || match == nil {
+			continue
+		}
+		action := ReplayAction{}
+		action.ActionID, _ = strconv.Atoi(match[1])
+		var body struct {
+			ChampionID int  `json:"championId"`
+			Completed  bool `json:"completed"`
+		}
+		if err := json.Unmarshal(request.Body, &body); err == nil {
+			action.ChampionID = body.ChampionID
+			action.Completed = body.Completed
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// Mutations 获取回放中所有修改类请求（非GET）
+func (r *ReplayResult) Mutations() []ReplayRequest {
+	var mutations []ReplayRequest
+	for _, request := range r.Requests {
+		if request.Method != "GET" {
+			mutations = append(mutations, request)
+		}
+	}
+	return mutations
+}
+
+// replayTransport 记录所有请求并返回预设响应的REST传输层
+// 预设响应的键为 "METHOD path"，未预设的GET请求返回404，其他请求返回204
+type replayTransport struct {
+	responses map[string]json.RawMessage
+	requests  []ReplayRequest
+	mu        sync.Mutex
+}
+
+// Do 实现RESTTransport
+func (t *replayTransport) Do(creds *LCUCredentials, method, path string, body []byte) (int, []byte, error) {
+	request := ReplayRequest{Method: method, Path: path}
+	if len(body) > 0 {
+		request.Body = body
+	}
+
+	t.mu.Lock()
+	t.requests = append(t.requests, request)
+	t.mu.Unlock()
+
+	if response, ok := t.responses[method+" "+path]; ok {
+		return http.StatusOK, response, nil
+	}
+	if method == "GET" {
+		return http.StatusNotFound, []byte(`{"message":"not recorded"}`), nil
+	}
+	return http.StatusNoContent, nil, nil
+}
+
+// ReplaySession 将录制的消息按顺序交给LCU连接器处理，返回连接器发出的所有请求
+// 回放时所有异步操作同步执行且不等待延迟，保证结果可重复
+func ReplaySession(frames []RecordedFrame, config *Config, responses map[string]json.RawMessage) (*ReplayResult, error) {
+	// 使用空的内存数据，不读取用户本地的英雄数据和各种库，保证回放结果只取决于录制文件和配置
+	app := &App{
+		config:          config,
+		championManager: NewChampionManager(),
+		matchups:        NewMatchupTable(),
+		runeLibrary:     NewRuneLibrary(),
+		itemSetLibrary:  NewItemSetLibrary(),
+		history:         NewGameHistory(),
+		matchHistory:    NewMatchHistoryCache(),
+		mastery:         NewMasteryService(),
+	}
+
+	transport := &replayTransport{responses: responses}
+	lcu := NewLCUConnector(app, WithTransport(transport))
+	lcu.credentials = &LCUCredentials{Port: 0, Token: "replay", Protocol: "https"}
+	lcu.spawn = func(f func()) { f() }
+	lcu.sleep = func(time.Duration) {}
+	lcu.wait = func(_ time.Duration, cancel <-chan struct{}) bool {
+		select {
+		case <-cancel:
+			return false
+		default:
+			return true
+		}
+	}
+	lcu.setConnected(true)
+	app.lcuConnector = lcu
+
+	result := &ReplayResult{}
+	for i, frame := range frames {
+		var msg []interface{}
+		if err := json.Unmarshal(frame.Frame, &msg); err != nil {
+			return nil, fmt.Errorf("frame %d: %w", i, err)
+		}
+		lcu.handleEvent(msg)
+		result.Frames++
+	}
+
+	transport.mu.Lock()
+	result.Requests = append(result.Requests, transport.requests...)
+	transport.mu.Unlock()
+
+	return result, nil
+}
+
+// ReplayFile 回放录制文件
+func ReplayFile(path string, config *Config) (*ReplayResult, error) {
+	frames, err := LoadRecording(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load recording: %w", err)
+	}
+	return ReplaySession(frames, config, nil)
+}
+
+// runReplay 使用当前配置回放录制文件并输出发出的修改请求，返回进程退出码
+func runReplay(path string) int {
+	config, err := LoadConfig()
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to load config: %v\n", err)
+		return 1
+	}
+
+	result, err := ReplayFile(path, config)
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to replay %s: %v\n", path, err)
+		return 1
+	}
+
+	output, err := json.MarshalIndent(map[string]interface{}{
+		"frames":    result.Frames,
+		"actions":   result.Actions(),
+		"mutations": result.Mutations(),
+	}, "", "  ")
+	if err != nil {
+		fmt.Printf("[ERROR] Failed to marshal replay result: %v\n", err)
+		return 1
+	}
+
+	fmt.Println(string(output))
+	return 0
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// replayTestConfig 回放测试使用的配置：自动Ban 238，中路自动选择157
+func replayTestConfig() *Config {
+	config := DefaultConfig()
+	banID, pickID := 238, 157
+	config.AutoBanEnabled = true
+	config.AutoBanChampionID = &banID
+	config.AutoPickEnabled = true
+	config.PositionChampions = map[string]*int{"MIDDLE": &pickID}
+	return config
+}
+
+func TestReplayChampSelectBanPick(t *testing.T) {
+	result, err := ReplayFile("testdata/champselect_ban_pick.jsonl", replayTestConfig())
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	if result.Frames != 3 {
+		t.Errorf("frames = %d, want 3", result.Frames)
+	}
+
+	wantActions := []ReplayAction{
+		{ActionID: 3, ChampionID: 238, Completed: true},
+		{ActionID: 9, ChampionID: 157, Completed: true},
+	}
+	if got := result.Actions(); !reflect.DeepEqual(got, wantActions) {
+		t.Errorf("actions = %+v, want %+v", got, wantActions)
+	}
+
+	wantMutations := []ReplayRequest{
+		{Method: "PATCH", Path: "/lol-champ-select/v1/session/actions/3", Body: json.RawMessage(`{"championId":238,"completed":true}`)},
+		{Method: "PATCH", Path: "/lol-champ-select/v1/session/actions/9", Body: json.RawMessage(`{"championId":157,"completed":true}`)},
+	}
+	if got := result.Mutations(); !reflect.DeepEqual(got, wantMutations) {
+		t.Errorf("mutations = %s, want %s", mustMarshal(t, got), mustMarshal(t, wantMutations))
+	}
+}
+
+// mustMarshal 将值序列化为JSON便于输出比较结果
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+	return string(data)
+}
+
+func TestReplayDodgeRequeue(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoRequeueEnabled = true
+
+	result, err := ReplayFile("testdata/dodge_requeue.jsonl", config)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	want := []ReplayRequest{
+		{Method: "POST", Path: "/lol-lobby/v2/lobby/matchmaking/search"},
+	}
+	if got := result.Mutations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mutations = %s, want %s", mustMarshal(t, got), mustMarshal(t, want))
+	}
+}
+
+func TestReplayReadyCheckBeforePhase(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoAcceptEnabled = true
+
+	result, err := ReplayFile("testdata/ready_check_before_phase.jsonl", config)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	want := []ReplayRequest{
+		{Method: "POST", Path: "/lol-matchmaking/v1/ready-check/accept"},
+	}
+	if got := result.Mutations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mutations = %s, want %s", mustMarshal(t, got), mustMarshal(t, want))
+	}
+}
+
+func TestReplayPickFallsBackWhenBannedOrTaken(t *testing.T) {
+	config := replayTestConfig()
+	config.AutoBanEnabled = false
+	// 157被敌方Ban，238被队友选走，应使用英雄池中的下一个英雄
+	config.PositionChampionPools = map[string][]int{"MIDDLE": {238, 103}}
+
+	result, err := ReplayFile("testdata/pick_fallback_banned.jsonl", config)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	want := []ReplayAction{{ActionID: 9, ChampionID: 103, Completed: true}}
+	if got := result.Actions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %+v, want %+v", got, want)
+	}
+}
+
+func TestReplayAramRerollUnlistedChampion(t *testing.T) {
+	config := DefaultConfig()
+	config.AramEnabled = true
+	config.AramRerollEnabled = true
+	config.AramRerollThreshold = 10
+	// 当前英雄12和备选席中的99都不在优先级列表中，列表短于阈值时也应重随
+	config.AramTierList = []int{1, 2, 3}
+
+	frames, err := LoadRecording("testdata/aram_unlisted_reroll.jsonl")
+	if err != nil {
+		t.Fatalf("failed to load recording: %v", err)
+	}
+	responses := map[string]json.RawMessage{
+		"GET /lol-summoner/v1/current-summoner/rerollPoints": json.RawMessage(`{"numberOfRolls":1}`),
+	}
+	result, err := ReplaySession(frames, config, responses)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	want := []ReplayRequest{
+		{Method: "POST", Path: "/lol-champ-select/v1/session/my-selection/reroll"},
+	}
+	if got := result.Mutations(); !reflect.DeepEqual(got, want) {
+		t.Errorf("mutations = %s, want %s", mustMarshal(t, got), mustMarshal(t, want))
+	}
+}
+
+func TestReplayMasteryPickSkipsBannedAndTaken(t *testing.T) {
+	config := DefaultConfig()
+	config.AutoPickEnabled = true
+	config.PickOrderByMastery = true
+
+	frames, err := LoadRecording("testdata/mastery_pick_skips_taken.jsonl")
+	if err != nil {
+		t.Fatalf("failed to load recording: %v", err)
+	}
+	// 成就最高的157被Ban，其次的238被队友选走
+	responses := map[string]json.RawMessage{
+		"GET /lol-summoner/v1/current-summoner":                  json.RawMessage(`{"summonerId":1}`),
+		"GET /lol-collections/v1/inventories/1/champion-mastery": json.RawMessage(`[{"championId":103,"championPoints":100000},{"championId":157,"championPoints":500000},{"championId":238,"championPoints":300000}]`),
+	}
+	result, err := ReplaySession(frames, config, responses)
+	if err != nil {
+		t.Fatalf("replay failed: %v", err)
+	}
+
+	want := []ReplayAction{{ActionID: 9, ChampionID: 103, Completed: true}}
+	if got := result.Actions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("actions = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+)
+
+// Riot客户端中英雄联盟的产品ID和版本线
+const (
+	leagueProductID = "league_of_legends"
+	leaguePatchline = "live"
+)
+
+// RiotClientStatus Riot客户端状态
+type RiotClientStatus struct {
+	Connected        bool   `json:"connected"`
+	LoggedIn         bool   `json:"logged_in"`
+	Region           string `json:"region,omitempty"`
+	Locale           string `json:"locale,omitempty"`
+	LeagueRunning    bool   `json:"league_running"`
+	CredentialSource string `json:"credential_source,omitempty"`
+}
+
+// RiotClientConnector Riot客户端本地接口的连接器
+// 与LCU是两个独立的进程，各自有lockfile、端口和令牌
+type RiotClientConnector struct {
+	transport   RESTTransport
+	providers   []CredentialProvider
+	credentials *LCUCredentials
+	mu          sync.RWMutex
+}
+
+// NewRiotClientConnector 创建Riot客户端连接器
+func NewRiotClientConnector(config *Config) *RiotClientConnector {
+	return &RiotClientConnector{
+		transport: NewHTTPTransport(lcuHost(config), riotTLSConfig(lcuHost(config), skipTLSVerify(config))),
+		providers: []CredentialProvider{
+			&lockfileCredentialProvider{candidates: riotClientConfigDirs},
+		},
+	}
+}
+
+// riotClientConfigDirs 返回Riot客户端存放lockfile的目录
+func riotClientConfigDirs() []string {
+	switch runtime.GOOS {
+	case "windows":
+		localAppData := os.Getenv("LOCALAPPDATA")
+		if localAppData == "" {
+			return nil
+		}
+		return []string{filepath.Join(localAppData, "Riot Games", "Riot Client", "Config")}
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil
+		}
+		return []string{filepath.Join(home, "Library", "Application Support", "Riot Games", "Riot Client", "Config")}
+	default:
+		// Linux下通过Wine运行
+		var dirs []string
+		for _, dir := range leagueInstallCandidates() {
+			driveC := filepath.Dir(filepath.Dir(dir))
+			users, err := filepath.Glob(filepath.Join(driveC, "users", "*", "AppData", "Local", "Riot Games", "Riot Client", "Config"))
+			if err == nil {
+				dirs = append(dirs, users...)
+			}
+		}
+		return dirs
+	}
+}
+
+// Connect 查找凭据并测试连接，Riot客户端重启后端口和令牌会变化，因此每次都重新读取
+func (rc *RiotClientConnector) Connect() error {
+	creds, err := findCredentials(rc.providers)
+	if err != nil {
+		rc.setCredentials(nil)
+		return fmt.Errorf("failed to find Riot Client credentials: %w", err)
+	}
+
+	rc.setCredentials(creds)
+	if _, err := rc.requestRaw("GET", "/riotclient/region-locale", nil); err != nil {
+		rc.setCredentials(nil)
+		return fmt.Errorf("failed to test Riot Client connection: %w", err)
+	}
+
+	return nil
+}
+
+// setCredentials 更新当前凭据
+func (rc *RiotClientConnector) setCredentials(creds *LCUCredentials) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.credentials = creds
+}
+
+// IsConnected 检查是否已连接
+func (rc *RiotClientConnector) IsConnected() bool {
+	rc.mu.RLock()
+	defer rc.mu.RUnlock()
+	return rc.credentials != nil
+}
+
+// requestRaw 发送请求到Riot客户端接口并返回原始响应体
+func (rc *RiotClientConnector) requestRaw(method, path string, body interface{}) ([]byte, error) {
+	rc.mu.RLock()
+	creds := rc.credentials
+	rc.mu.RUnlock()
+
+	if creds == nil {
+		return nil, fmt.Errorf("not connected to Riot Client")
+	}
+
+	var reqBody []byte
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reqBody = jsonData
+	}
+
+	status, respBody, err := rc.transport.Do(creds, method, path, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if status < 200 || status >= 300 {
+		return nil, fmt.Errorf("HTTP %d: %s", status, string(respBody))
+	}
+	return respBody, nil
+}
+
+// requestJSON 发送请求并将响应解析到out
+func (rc *RiotClientConnector) requestJSON(method, path string, body interface{}, out interface{}) error {
+	data, err := rc.requestRaw(method, path, body)
+	if err != nil {
+		return err
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// IsLoggedIn 检查是否已登录Riot账号，未登录时授权接口返回404
+func (rc *RiotClientConnector) IsLoggedIn() bool {
+	_, err := rc.requestRaw("GET", "/rso-auth/v1/authorization", nil)
+	return err == nil
+}
+
+// GetRegionLocale 获取账号所在区域和语言
+func (rc *RiotClientConnector) GetRegionLocale() (region, locale string, err error) {
+	var result struct {
+		Region string `json:"region"`
+		Locale string `json:"locale"`
+	}
+	if err := rc.requestJSON("GET", "/riotclient/region-locale", nil, &result); err != nil {
+		return "", "", err
+	}
+	return result.Region, result.Locale, nil
+}
+
+// IsLeagueRunning 检查英雄联盟客户端是否已由Riot客户端启动
+func (rc *RiotClientConnector) IsLeagueRunning() (bool, error) {
+	var sessions map[string]struct {
+		ProductID string `json:"productId"`
+	}
+	if err := rc.requestJSON("GET", "/product-session/v1/external-sessions", nil, &sessions); err != nil {
+		return false, err
+	}
+	for _, session := range sessions {
+		if session.ProductID == leagueProductID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// LaunchLeague 通过Riot客户端启动英雄联盟
+func (rc *RiotClientConnector) LaunchLeague() error {
+	path := fmt.Sprintf("/product-launcher/v1/products/%s/patchlines/%s", leagueProductID, leaguePatchline)
+	_, err := rc.requestRaw("POST", path, nil)
+	return err
+}
+
+// GetStatus 获取Riot客户端的状态，未连接时尝试重新连接
+func (rc *RiotClientConnector) GetStatus() *RiotClientStatus {
+	status := &RiotClientStatus{}
+	if err := rc.Connect(); err != nil {
+		return status
+	}
+
+	status.Connected = true
+	rc.mu.RLock()
+	if rc.credentials != nil {
+		status.CredentialSource = rc.credentials.Source
+	}
+	rc.mu.RUnlock()
+
+	status.LoggedIn = rc.IsLoggedIn()
+	if region, locale, err := rc.GetRegionLocale(); err == nil {
+		status.Region = region
+		status.Locale = locale
+	}
+	if running, err := rc.IsLeagueRunning(); err == nil {
+		status.LeagueRunning = running
+	}
+
+	return status
+}
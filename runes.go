@@ -0,0 +1,147 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// RunePage 符文页
+type RunePage struct {
+	Name            string `json:"name"`
+	PrimaryStyleID  int    `json:"primaryStyleId"`
+	SubStyleID      int    `json:"subStyleId"`
+	SelectedPerkIDs []int  `json:"selectedPerkIds"`
+}
+
+// RuneLibrary 按英雄和位置存储的符文页库
+type RuneLibrary struct {
+	Pages  map[string]RunePage `json:"pages"` // 键为 "英雄ID" 或 "英雄ID:位置"
+	mu     sync.RWMutex
+	saveMu sync.Mutex // 保证文件按保存顺序写入
+}
+
+// championPositionKey 生成按英雄和位置存储的配置键，位置为空时表示该英雄的通用配置
+func championPositionKey(championID int, position string) string {
+	if position == "" {
+		return fmt.Sprintf("%d", championID)
+	}
+	return fmt.Sprintf("%d:%s", championID, strings.ToUpper(position))
+}
+
+// Validate 检查符文页是否完整
+func (p RunePage) Validate() error {
+	if p.PrimaryStyleID <= 0 || p.SubStyleID <= 0 {
+		return fmt.Errorf("rune page requires primary and sub style")
+	}
+	if p.PrimaryStyleID == p.SubStyleID {
+		return fmt.Errorf("primary and sub style must differ")
+	}
+	if len(p.SelectedPerkIDs) != 9 {
+		return fmt.Errorf("rune page requires 9 perks, got %d", len(p.SelectedPerkIDs))
+	}
+	return nil
+}
+
+// GetRunesPath 获取符文页库文件的完整路径
+func GetRunesPath() (string, error) {
+	return GetDataPath("runes.json")
+}
+
+// NewRuneLibrary 创建空的符文页库
+func NewRuneLibrary() *RuneLibrary {
+	return &RuneLibrary{Pages: make(map[string]RunePage)}
+}
+
+// LoadRuneLibrary 从文件加载符文页库
+func LoadRuneLibrary() (*RuneLibrary, error) {
+	library := NewRuneLibrary()
+
+	filename, err := GetRunesPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get runes path: %w", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return library, nil
+		}
+		return nil, fmt.Errorf("failed to read runes file: %w", err)
+	}
+
+	if err := json.Unmarshal(data, library); err != nil {
+		return nil, fmt.Errorf("failed to parse runes file: %w", err)
+	}
+	if library.Pages == nil {
+		library.Pages = make(map[string]RunePage)
+	}
+
+	return library, nil
+}
+
+// Save 保存符文页库到文件
+func (rl *RuneLibrary) Save() error {
+	filename, err := GetRunesPath()
+	if err != nil {
+		return fmt.Errorf("failed to get runes path: %w", err)
+	}
+
+	rl.saveMu.Lock()
+	defer rl.saveMu.Unlock()
+
+	rl.mu.RLock()
+	data, err := json.MarshalIndent(rl, "", "  ")
+	rl.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to marshal runes: %w", err)
+	}
+
+	if err := writeFileAtomic(filename, data, 0644); err != nil {
+		return fmt.Errorf("failed to write runes file: %w", err)
+	}
+
+	return nil
+}
+
+// Get 获取英雄在指定位置的符文页，没有位置专用的符文页时使用通用符文页
+func (rl *RuneLibrary) Get(championID int, position string) (RunePage, bool) {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	if position != "" {
+		if page, ok := rl.Pages[championPositionKey(championID, position)]; ok {
+			return page, true
+		}
+	}
+	page, ok := rl.Pages[championPositionKey(championID, "")]
+	return page, ok
+}
+
+// Set 设置英雄在指定位置的符文页
+func (rl *RuneLibrary) Set(championID int, position string, page RunePage) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.Pages[championPositionKey(championID, position)] = page
+}
+
+// Delete 删除英雄在指定位置的符文页
+func (rl *RuneLibrary) Delete(championID int, position string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.Pages, championPositionKey(championID, position))
+}
+
+// All 获取所有符文页的副本
+func (rl *RuneLibrary) All() map[string]RunePage {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	pages := make(map[string]RunePage, len(rl.Pages))
+	for key, page := range rl.Pages {
+		pages[key] = page
+	}
+	return pages
+}
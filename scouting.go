@@ -0,0 +1,102 @@
+package main
+
+import "fmt"
+
+const (
+	// scoutStreakThreshold 连胜或连败达到该局数时提示
+	scoutStreakThreshold = 3
+	// scoutLowWinRateGames 判断近期胜率过低至少需要的场次
+	scoutLowWinRateGames = 10
+	// scoutLowWinRate 近期胜率低于该值时提示
+	scoutLowWinRate = 0.4
+)
+
+// ScoutReport 选人阶段对一名玩家的侦察结果
+type ScoutReport struct {
+	PUUID         string           `json:"puuid"`
+	Name          string           `json:"name"`
+	Team          string           `json:"team"` // ally 或 enemy
+	CellID        int              `json:"cell_id"`
+	Position      string           `json:"position"`
+	ChampionID    int              `json:"champion_id"`
+	SummonerLevel int              `json:"summoner_level"`
+	Ranked        *RankedStats     `json:"ranked,omitempty"`  // 单双排段位
+	Recent        Performance      `json:"recent"`            // 最近一页战绩的表现
+	ChampionGames int              `json:"champion_games"`    // 最近战绩中使用当前英雄的场次
+	Mastery       *ChampionMastery `json:"mastery,omitempty"` // 当前英雄的成就
+	Flags         []string         `json:"flags"`
+	Loading       bool             `json:"loading"` // 数据仍在获取中
+}
+
+// scoutData 侦察一名玩家时从LCU获取的原始数据
+type scoutData struct {
+	Name          string
+	SummonerLevel int
+	Ranked        *RankedStats
+	Matches       []MatchRecord
+	Masteries     []ChampionMastery // 获取失败时为nil
+}
+
+// streak 统计最近连续相同结果的局数，返回局数和是否为连胜
+func streak(records []MatchRecord) (int, bool) {
+	if len(records) == 0 {
+		return 0, false
+	}
+	win := records[0].Win
+	count := 0
+	for _, record := range records {
+		if record.Win != win {
+			break
+		}
+		count++
+	}
+	return count, win
+}
+
+// buildScoutReport 根据侦察数据和当前英雄生成侦察结果
+func buildScoutReport(report ScoutReport, data *scoutData) ScoutReport {
+	report.Flags = []string{}
+	if data == nil {
+		report.Loading = true
+		return report
+	}
+
+	report.Name = data.Name
+	report.SummonerLevel = data.SummonerLevel
+	report.Ranked = data.Ranked
+	report.Recent = computePerformance(data.Matches)
+
+	if count, win := streak(data.Matches); count >= scoutStreakThreshold {
+		if win {
+			report.Flags = append(report.Flags, fmt.Sprintf("on a %d-game winning streak", count))
+		} else {
+			report.Flags = append(report.Flags, fmt.Sprintf("on a %d-game losing streak", count))
+		}
+	}
+	if report.Recent.Games >= scoutLowWinRateGames && report.Recent.WinRate < scoutLowWinRate {
+		report.Flags = append(report.Flags, "low recent win rate")
+	}
+
+	if report.ChampionID <= 0 {
+		return report
+	}
+
+	for _, record := range data.Matches {
+		if record.ChampionID == report.ChampionID {
+			report.ChampionGames++
+		}
+	}
+	for _, mastery := range data.Masteries {
+		if mastery.ChampionID == report.ChampionID {
+			m := mastery
+			report.Mastery = &m
+			break
+		}
+	}
+	// 成就数据获取失败时无法判断是否首次使用
+	if data.Masteries != nil && report.ChampionGames == 0 && (report.Mastery == nil || report.Mastery.ChampionPoints == 0) {
+		report.Flags = append(report.Flags, "first time on this champion")
+	}
+
+	return report
+}
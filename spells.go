@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// 召唤师技能ID
+const (
+	SpellCleanse  = 1
+	SpellExhaust  = 3
+	SpellFlash    = 4
+	SpellGhost    = 6
+	SpellHeal     = 7
+	SpellSmite    = 11
+	SpellTeleport = 12
+	SpellClarity  = 13
+	SpellIgnite   = 14
+	SpellBarrier  = 21
+	SpellMark     = 32
+)
+
+// modeSpells 各游戏模式可用的召唤师技能，未列出的模式不做校验
+var modeSpells = map[string]map[int]bool{
+	"CLASSIC": {
+		SpellCleanse: true, SpellExhaust: true, SpellFlash: true, SpellGhost: true, SpellHeal: true,
+		SpellSmite: true, SpellTeleport: true, SpellIgnite: true, SpellBarrier: true,
+	},
+	"ARAM": {
+		SpellCleanse: true, SpellExhaust: true, SpellFlash: true, SpellGhost: true, SpellHeal: true,
+		SpellClarity: true, SpellIgnite: true, SpellBarrier: true, SpellMark: true,
+	},
+}
+
+// SpellPair 一组召唤师技能，Spell1对应D键，Spell2对应F键
+type SpellPair struct {
+	Spell1 int `json:"spell1"`
+	Spell2 int `json:"spell2"`
+}
+
+// WithFlashOn 按闪现位置偏好调整技能顺序
+func (p SpellPair) WithFlashOn(flashOnF bool) SpellPair {
+	if flashOnF && p.Spell1 == SpellFlash {
+		return SpellPair{Spell1: p.Spell2, Spell2: p.Spell1}
+	}
+	if !flashOnF && p.Spell2 == SpellFlash {
+		return SpellPair{Spell1: p.Spell2, Spell2: p.Spell1}
+	}
+	return p
+}
+
+// Validate 检查技能组在指定游戏模式和位置下是否可用
+func (p SpellPair) Validate(gameMode string, position string) error {
+	if p.Spell1 <= 0 || p.Spell2 <= 0 {
+		return fmt.Errorf("both spells are required")
+	}
+	if p.Spell1 == p.Spell2 {
+		return fmt.Errorf("spells must differ")
+	}
+
+	allowed, ok := modeSpells[strings.ToUpper(gameMode)]
+	if !ok {
+		return nil
+	}
+	for _, spell := range []int{p.Spell1, p.Spell2} {
+		if !allowed[spell] {
+			return fmt.Errorf("spell %d is not available in %s", spell, gameMode)
+		}
+		// 排位等分配了位置的对局中只有打野可以带惩戒
+		if spell == SpellSmite && position != "" && !strings.EqualFold(position, "JUNGLE") {
+			return fmt.Errorf("smite is only allowed in jungle, assigned %s", position)
+		}
+	}
+	return nil
+}
+
+// GetSpellsForChampion 获取英雄在指定位置的召唤师技能
+// 查找顺序: 英雄+位置 > 英雄 > 位置
+func (c *Config) GetSpellsForChampion(championID int, position string) (SpellPair, bool) {
+	if c.ChampionSpells == nil {
+		return SpellPair{}, false
+	}
+	keys := []string{championPositionKey(championID, "")}
+	if position != "" {
+		keys = []string{
+			championPositionKey(championID, position),
+			championPositionKey(championID, ""),
+			strings.ToUpper(position),
+		}
+	}
+	for _, key := range keys {
+		if pair, ok := c.ChampionSpells[key]; ok {
+			return pair.WithFlashOn(c.FlashOnF), true
+		}
+	}
+	return SpellPair{}, false
+}
@@ -1,49 +1,251 @@
 package main
 
 import (
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 )
 
-// GetUserDataDir 获取用户数据目录下的AutoBP.exe文件夹路径
+const (
+	// dataDirEnv 覆盖数据目录的环境变量
+	dataDirEnv = "AUTOBP_DATA_DIR"
+	// dataDirFlag 覆盖数据目录的命令行参数
+	dataDirFlag = "--data-dir"
+	// portableMarker 便携模式标记文件，放在可执行文件旁边即可启用
+	portableMarker = "portable.txt"
+	// portableDataDir 便携模式下可执行文件旁的数据目录名
+	portableDataDir = "AutoBP-data"
+)
+
+var (
+	dataDirOnce sync.Once
+	dataDir     string
+	dataDirErr  error
+	portable    bool
+)
+
+// GetUserDataDir 获取用户数据目录
+// 优先级: 命令行参数 --data-dir > 环境变量 AUTOBP_DATA_DIR > 便携模式 > 系统配置目录
 func GetUserDataDir() (string, error) {
-	// 获取APPDATA目录
-	appData := os.Getenv("APPDATA")
-	if appData == "" {
-		// 如果APPDATA不存在，回退到用户主目录
-		userHome, err := os.UserHomeDir()
-		if err != nil {
-			return "", err
-		}
-		appData = userHome
+	dataDirOnce.Do(func() {
+		dataDir, dataDirErr = resolveUserDataDir()
+	})
+	if dataDirErr != nil {
+		return "", dataDirErr
 	}
-	
-	// 使用Wails的标准数据目录：%APPDATA%\[BinaryName.exe]
-	// 通常生成在: C:\Users\用户名\AppData\Roaming\AutoBP.exe\
-	autoBPDir := filepath.Join(appData, "AutoBP.exe")
-	
+
 	// 确保目录存在
-	if err := os.MkdirAll(autoBPDir, 0755); err != nil {
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
 		return "", err
 	}
-	
-	return autoBPDir, nil
+
+	return dataDir, nil
 }
 
-// GetConfigPath 获取配置文件的完整路径
-func GetConfigPath() (string, error) {
-	dataDir, err := GetUserDataDir()
+// resolveUserDataDir 解析数据目录，默认目录首次使用时迁移旧数据
+func resolveUserDataDir() (string, error) {
+	if dir := flagValue(os.Args[1:], dataDirFlag); dir != "" {
+		return filepath.Abs(dir)
+	}
+	if dir := os.Getenv(dataDirEnv); dir != "" {
+		return filepath.Abs(dir)
+	}
+	if dir := portableUserDataDir(); dir != "" {
+		portable = true
+		return dir, nil
+	}
+
+	dir, err := defaultUserDataDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dataDir, "config.json"), nil
+
+	if legacyDir := legacyUserDataDir(); legacyDir != "" && legacyDir != dir {
+		if err := migrateUserData(legacyDir, dir); err != nil {
+			fmt.Printf("[WARNING] Failed to migrate data from %s: %v\n", legacyDir, err)
+		}
+	}
+
+	return dir, nil
 }
 
-// GetChampionsPath 获取英雄数据文件的完整路径
-func GetChampionsPath() (string, error) {
+// flagValue 从命令行参数中读取 "--name value" 或 "--name=value" 形式的参数
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, name+"="); ok {
+			return value
+		}
+		if arg == name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// portableUserDataDir 检查可执行文件旁是否有便携模式标记，有则返回其旁边的数据目录
+func portableUserDataDir() string {
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+
+	exeDir := filepath.Dir(exe)
+	if _, err := os.Stat(filepath.Join(exeDir, portableMarker)); err != nil {
+		return ""
+	}
+	return filepath.Join(exeDir, portableDataDir)
+}
+
+// IsPortableMode 是否以便携模式运行
+func IsPortableMode() bool {
+	GetUserDataDir()
+	return portable
+}
+
+// defaultUserDataDir 获取系统默认的数据目录
+func defaultUserDataDir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	// Windows下沿用Wails的标准数据目录：%APPDATA%\[BinaryName.exe]
+	// 通常生成在: C:\Users\用户名\AppData\Roaming\AutoBP.exe\
+	if runtime.GOOS == "windows" {
+		return filepath.Join(configDir, "AutoBP.exe"), nil
+	}
+
+	// Linux: $XDG_CONFIG_HOME/AutoBP 或 ~/.config/AutoBP
+	// macOS: ~/Library/Application Support/AutoBP
+	return filepath.Join(configDir, "AutoBP"), nil
+}
+
+// legacyUserDataDir 获取旧版本使用的数据目录
+// 旧版本在没有APPDATA时会回退到用户主目录下的AutoBP.exe文件夹
+func legacyUserDataDir() string {
+	if appData := os.Getenv("APPDATA"); appData != "" {
+		return filepath.Join(appData, "AutoBP.exe")
+	}
+	userHome, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(userHome, "AutoBP.exe")
+}
+
+// migrateUserData 将旧目录中的数据文件复制到新目录，已存在的文件不会被覆盖
+func migrateUserData(fromDir, toDir string) error {
+	entries, err := os.ReadDir(fromDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	if err := os.MkdirAll(toDir, 0755); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		target := filepath.Join(toDir, entry.Name())
+		if _, err := os.Stat(target); err == nil {
+			continue
+		}
+
+		if err := copyFile(filepath.Join(fromDir, entry.Name()), target); err != nil {
+			return fmt.Errorf("failed to copy %s: %w", entry.Name(), err)
+		}
+		fmt.Printf("[INFO] Migrated %s from %s\n", entry.Name(), fromDir)
+	}
+
+	return nil
+}
+
+// copyFile 复制单个文件
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(dst)
+		return err
+	}
+
+	return out.Close()
+}
+
+// writeFileAtomic 先写入同目录下的临时文件再重命名，避免写入中途退出时留下不完整的文件
+func writeFileAtomic(filename string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, perm); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, filename); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+// GetDataPath 获取数据目录下文件的完整路径
+// 所有需要持久化的数据（配置、缓存、日志、历史记录等）都应通过此方法取得路径，
+// 以便在便携模式或自定义数据目录下统一存放
+func GetDataPath(elem ...string) (string, error) {
 	dataDir, err := GetUserDataDir()
 	if err != nil {
 		return "", err
 	}
-	return filepath.Join(dataDir, "champions.json"), nil
-}
\ No newline at end of file
+	path := filepath.Join(append([]string{dataDir}, elem...)...)
+	if len(elem) > 1 {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// GetConfigPath 获取配置文件的完整路径
+func GetConfigPath() (string, error) {
+	return GetDataPath("config.json")
+}
+
+// GetChampionsPath 获取英雄数据文件的完整路径
+func GetChampionsPath() (string, error) {
+	return GetDataPath("champions.json")
+}